@@ -0,0 +1,11 @@
+package main
+
+import (
+	"github.com/titan-data/remote-sdk-go/memory"
+	"github.com/titan-data/remote-sdk-go/remote"
+)
+
+func main() {
+	remote.Register(memory.New())
+	remote.Serve(memory.Type)
+}
@@ -3,7 +3,14 @@
  */
 package echo
 
-import "github.com/titan-data/remote-sdk-go/remote"
+import (
+	"context"
+	"errors"
+	"github.com/titan-data/remote-sdk-go/remote"
+	"io"
+	"io/ioutil"
+	"log"
+)
 
 type EchoRemote struct {
 }
@@ -12,6 +19,14 @@ func (m EchoRemote) Type() (string, error) {
 	return "echo", nil
 }
 
+func (m EchoRemote) Manifest() remote.Manifest {
+	return remote.Manifest{Version: "1.0.0", Description: "Echo remote for testing the SDK"}
+}
+
+func (m EchoRemote) Capabilities() remote.Capabilities {
+	return remote.Capabilities{TagFiltering: true}
+}
+
 func (m EchoRemote) FromURL(url string, additionalProperties map[string]string) (map[string]interface{}, error) {
 	ret := map[string]interface{}{
 		"url": url,
@@ -73,3 +88,59 @@ func (m EchoRemote) GetCommit(properties map[string]interface{}, parameters map[
 		return nil, nil
 	}
 }
+
+func (m EchoRemote) StartOperation(ctx context.Context, operation remote.Operation) (map[string]interface{}, error) {
+	return map[string]interface{}{"operation": operation.Id}, nil
+}
+
+func (m EchoRemote) PushMetadata(ctx context.Context, operation remote.Operation, commit remote.Commit, isUpdate bool) error {
+	if commit.Id != operation.CommitId {
+		return remote.Errorf(remote.ErrInvalidProperty, "commit '%s' does not match operation commit '%s'", commit.Id, operation.CommitId)
+	}
+	return nil
+}
+
+func (m EchoRemote) SyncVolume(ctx context.Context, operation remote.Operation, volume remote.Volume) error {
+	if volume.Name == "" {
+		return errors.New("missing volume name")
+	}
+	remote.ReportProgress(ctx, remote.Progress{Phase: string(operation.Type), Message: volume.Name})
+	return nil
+}
+
+func (m EchoRemote) EndOperation(ctx context.Context, operation remote.Operation) error {
+	return nil
+}
+
+func (m EchoRemote) FailOperation(ctx context.Context, operation remote.Operation, reason string) error {
+	// Plugins log to stderr, which is forwarded to the host's logger
+	log.Printf("[WARN] operation %s failed: %s", operation.Id, reason)
+	return nil
+}
+
+func (m EchoRemote) UploadVolume(ctx context.Context, operation remote.Operation, volume string, r io.Reader) error {
+	_, err := io.Copy(ioutil.Discard, r)
+	return err
+}
+
+func (m EchoRemote) DownloadVolume(ctx context.Context, operation remote.Operation, volume string, w io.Writer) error {
+	_, err := io.WriteString(w, volume)
+	return err
+}
+
+func (m EchoRemote) VolumeOffset(ctx context.Context, operation remote.Operation, volume string) (int64, error) {
+	return 0, nil
+}
+
+func (m EchoRemote) UploadVolumeAt(ctx context.Context, operation remote.Operation, volume string, offset int64, r io.Reader) (int64, error) {
+	n, err := io.Copy(ioutil.Discard, r)
+	return offset + n, err
+}
+
+func (m EchoRemote) DownloadVolumeAt(ctx context.Context, operation remote.Operation, volume string, offset int64, w io.Writer) error {
+	if offset > int64(len(volume)) {
+		offset = int64(len(volume))
+	}
+	_, err := io.WriteString(w, volume[offset:])
+	return err
+}
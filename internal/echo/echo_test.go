@@ -4,8 +4,12 @@
 package echo
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/titan-data/remote-sdk-go/remote"
+	"strings"
 	"testing"
 )
 
@@ -17,6 +21,12 @@ func TestType(t *testing.T) {
 	}
 }
 
+func TestManifest(t *testing.T) {
+	m := EchoRemote{}.Manifest()
+	assert.Equal(t, "1.0.0", m.Version)
+	assert.NotEmpty(t, m.Description)
+}
+
 func TestToURL(t *testing.T) {
 	e := EchoRemote{}
 	u, props, err := e.ToURL(map[string]interface{}{"a": "b"})
@@ -95,3 +105,99 @@ func TestGetMissingCommit(t *testing.T) {
 		assert.Nil(t, commit)
 	}
 }
+
+func TestOperation(t *testing.T) {
+	e := EchoRemote{}
+	op := remote.Operation{Id: "op", Type: remote.OperationPush, CommitId: "commit"}
+	data, err := e.StartOperation(context.Background(), op)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "op", data["operation"])
+	}
+	op.Data = data
+	assert.NoError(t, e.PushMetadata(context.Background(), op, remote.Commit{Id: "commit"}, false))
+	assert.NoError(t, e.SyncVolume(context.Background(), op, remote.Volume{Name: "vol"}))
+	assert.NoError(t, e.EndOperation(context.Background(), op))
+	assert.NoError(t, e.FailOperation(context.Background(), op, "failed"))
+}
+
+func TestPushMetadataMismatch(t *testing.T) {
+	e := EchoRemote{}
+	op := remote.Operation{Id: "op", Type: remote.OperationPush, CommitId: "commit"}
+	err := e.PushMetadata(context.Background(), op, remote.Commit{Id: "other"}, false)
+	assert.True(t, errors.Is(err, remote.ErrInvalidProperty))
+}
+
+func TestSyncVolumeNoName(t *testing.T) {
+	e := EchoRemote{}
+	op := remote.Operation{Id: "op", Type: remote.OperationPull, CommitId: "commit"}
+	assert.Error(t, e.SyncVolume(context.Background(), op, remote.Volume{}))
+}
+
+func TestUploadVolume(t *testing.T) {
+	e := EchoRemote{}
+	err := e.UploadVolume(context.Background(), remote.Operation{}, "vol", strings.NewReader("data"))
+	assert.NoError(t, err)
+}
+
+func TestDownloadVolume(t *testing.T) {
+	e := EchoRemote{}
+	var buf bytes.Buffer
+	err := e.DownloadVolume(context.Background(), remote.Operation{}, "vol", &buf)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "vol", buf.String())
+	}
+}
+
+func TestUploadVolumeAt(t *testing.T) {
+	e := EchoRemote{}
+	offset, err := e.UploadVolumeAt(context.Background(), remote.Operation{}, "vol", 10, strings.NewReader("data"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(14), offset)
+	}
+}
+
+func TestDownloadVolumeAt(t *testing.T) {
+	e := EchoRemote{}
+	var buf bytes.Buffer
+	err := e.DownloadVolumeAt(context.Background(), remote.Operation{}, "volume", 2, &buf)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "lume", buf.String())
+	}
+}
+
+func TestSyncVolumeProgress(t *testing.T) {
+	e := EchoRemote{}
+	events := []remote.Progress{}
+	ctx := remote.WithProgress(context.Background(), remote.ProgressFunc(func(p remote.Progress) {
+		events = append(events, p)
+	}))
+	op := remote.Operation{Id: "op", Type: remote.OperationPull, CommitId: "commit"}
+	if assert.NoError(t, e.SyncVolume(ctx, op, remote.Volume{Name: "vol"})) {
+		assert.Equal(t, []remote.Progress{{Phase: "pull", Message: "vol"}}, events)
+	}
+}
+
+func TestInProcess(t *testing.T) {
+	reg := remote.NewRegistry()
+	defer reg.Clear()
+	reg.Register(EchoRemote{})
+	e, err := reg.LoadInProcess("echo")
+	if assert.NoError(t, err) {
+		props, err := e.FromURL("echo://echo", map[string]string{"a": "b"})
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]interface{}{"url": "echo://echo", "a": "b"}, props)
+		}
+
+		events := []remote.Progress{}
+		ctx := remote.WithProgress(context.Background(), remote.ProgressFunc(func(p remote.Progress) {
+			events = append(events, p)
+		}))
+		o, ok := remote.Operations(e)
+		if assert.True(t, ok) {
+			op := remote.Operation{Id: "op", Type: remote.OperationPush, CommitId: "commit"}
+			if assert.NoError(t, o.SyncVolume(ctx, op, remote.Volume{Name: "vol"})) {
+				assert.Equal(t, []remote.Progress{{Phase: "push", Message: "vol"}}, events)
+			}
+		}
+	}
+}
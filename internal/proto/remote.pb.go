@@ -25,6 +25,37 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type PromptRequest_Kind int32
+
+const (
+	PromptRequest_TEXT    PromptRequest_Kind = 0
+	PromptRequest_SECRET  PromptRequest_Kind = 1
+	PromptRequest_CONFIRM PromptRequest_Kind = 2
+	PromptRequest_CHOICE  PromptRequest_Kind = 3
+)
+
+var PromptRequest_Kind_name = map[int32]string{
+	0: "TEXT",
+	1: "SECRET",
+	2: "CONFIRM",
+	3: "CHOICE",
+}
+
+var PromptRequest_Kind_value = map[string]int32{
+	"TEXT":    0,
+	"SECRET":  1,
+	"CONFIRM": 2,
+	"CHOICE":  3,
+}
+
+func (x PromptRequest_Kind) String() string {
+	return proto.EnumName(PromptRequest_Kind_name, int32(x))
+}
+
+func (PromptRequest_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{44, 0}
+}
+
 type GetTypeRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -95,6 +126,391 @@ func (m *GetTypeResponse) GetType() string {
 	return ""
 }
 
+type GetCapabilitiesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetCapabilitiesRequest) Reset()         { *m = GetCapabilitiesRequest{} }
+func (m *GetCapabilitiesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetCapabilitiesRequest) ProtoMessage()    {}
+func (*GetCapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{2}
+}
+
+func (m *GetCapabilitiesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCapabilitiesRequest.Unmarshal(m, b)
+}
+func (m *GetCapabilitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCapabilitiesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetCapabilitiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCapabilitiesRequest.Merge(m, src)
+}
+func (m *GetCapabilitiesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetCapabilitiesRequest.Size(m)
+}
+func (m *GetCapabilitiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCapabilitiesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCapabilitiesRequest proto.InternalMessageInfo
+
+type GetCapabilitiesResponse struct {
+	Cancellation         bool     `protobuf:"varint,1,opt,name=cancellation,proto3" json:"cancellation,omitempty"`
+	Pagination           bool     `protobuf:"varint,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	Streaming            bool     `protobuf:"varint,3,opt,name=streaming,proto3" json:"streaming,omitempty"`
+	TagFiltering         bool     `protobuf:"varint,4,opt,name=tag_filtering,json=tagFiltering,proto3" json:"tag_filtering,omitempty"`
+	Operations           bool     `protobuf:"varint,5,opt,name=operations,proto3" json:"operations,omitempty"`
+	VolumeTransfer       bool     `protobuf:"varint,6,opt,name=volume_transfer,json=volumeTransfer,proto3" json:"volume_transfer,omitempty"`
+	ResumableTransfer    bool     `protobuf:"varint,7,opt,name=resumable_transfer,json=resumableTransfer,proto3" json:"resumable_transfer,omitempty"`
+	Schema               bool     `protobuf:"varint,8,opt,name=schema,proto3" json:"schema,omitempty"`
+	Delete               bool     `protobuf:"varint,9,opt,name=delete,proto3" json:"delete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetCapabilitiesResponse) Reset()         { *m = GetCapabilitiesResponse{} }
+func (m *GetCapabilitiesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetCapabilitiesResponse) ProtoMessage()    {}
+func (*GetCapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{3}
+}
+
+func (m *GetCapabilitiesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCapabilitiesResponse.Unmarshal(m, b)
+}
+func (m *GetCapabilitiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCapabilitiesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetCapabilitiesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCapabilitiesResponse.Merge(m, src)
+}
+func (m *GetCapabilitiesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetCapabilitiesResponse.Size(m)
+}
+func (m *GetCapabilitiesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCapabilitiesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCapabilitiesResponse proto.InternalMessageInfo
+
+func (m *GetCapabilitiesResponse) GetCancellation() bool {
+	if m != nil {
+		return m.Cancellation
+	}
+	return false
+}
+
+func (m *GetCapabilitiesResponse) GetPagination() bool {
+	if m != nil {
+		return m.Pagination
+	}
+	return false
+}
+
+func (m *GetCapabilitiesResponse) GetStreaming() bool {
+	if m != nil {
+		return m.Streaming
+	}
+	return false
+}
+
+func (m *GetCapabilitiesResponse) GetTagFiltering() bool {
+	if m != nil {
+		return m.TagFiltering
+	}
+	return false
+}
+
+func (m *GetCapabilitiesResponse) GetOperations() bool {
+	if m != nil {
+		return m.Operations
+	}
+	return false
+}
+
+func (m *GetCapabilitiesResponse) GetVolumeTransfer() bool {
+	if m != nil {
+		return m.VolumeTransfer
+	}
+	return false
+}
+
+func (m *GetCapabilitiesResponse) GetResumableTransfer() bool {
+	if m != nil {
+		return m.ResumableTransfer
+	}
+	return false
+}
+
+func (m *GetCapabilitiesResponse) GetSchema() bool {
+	if m != nil {
+		return m.Schema
+	}
+	return false
+}
+
+func (m *GetCapabilitiesResponse) GetDelete() bool {
+	if m != nil {
+		return m.Delete
+	}
+	return false
+}
+
+type PropertySchema struct {
+	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string            `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Required             bool              `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	Default              *_struct.Value    `protobuf:"bytes,4,opt,name=default,proto3" json:"default,omitempty"`
+	Sensitive            bool              `protobuf:"varint,5,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+	Description          string            `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Enum                 []string          `protobuf:"bytes,7,rep,name=enum,proto3" json:"enum,omitempty"`
+	Pattern              string            `protobuf:"bytes,8,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Properties           []*PropertySchema `protobuf:"bytes,9,rep,name=properties,proto3" json:"properties,omitempty"`
+	Items                *PropertySchema   `protobuf:"bytes,10,opt,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PropertySchema) Reset()         { *m = PropertySchema{} }
+func (m *PropertySchema) String() string { return proto.CompactTextString(m) }
+func (*PropertySchema) ProtoMessage()    {}
+func (*PropertySchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{4}
+}
+
+func (m *PropertySchema) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PropertySchema.Unmarshal(m, b)
+}
+func (m *PropertySchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PropertySchema.Marshal(b, m, deterministic)
+}
+func (m *PropertySchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PropertySchema.Merge(m, src)
+}
+func (m *PropertySchema) XXX_Size() int {
+	return xxx_messageInfo_PropertySchema.Size(m)
+}
+func (m *PropertySchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_PropertySchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PropertySchema proto.InternalMessageInfo
+
+func (m *PropertySchema) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PropertySchema) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *PropertySchema) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+func (m *PropertySchema) GetDefault() *_struct.Value {
+	if m != nil {
+		return m.Default
+	}
+	return nil
+}
+
+func (m *PropertySchema) GetSensitive() bool {
+	if m != nil {
+		return m.Sensitive
+	}
+	return false
+}
+
+func (m *PropertySchema) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *PropertySchema) GetEnum() []string {
+	if m != nil {
+		return m.Enum
+	}
+	return nil
+}
+
+func (m *PropertySchema) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *PropertySchema) GetProperties() []*PropertySchema {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *PropertySchema) GetItems() *PropertySchema {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type GetSchemaRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSchemaRequest) Reset()         { *m = GetSchemaRequest{} }
+func (m *GetSchemaRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSchemaRequest) ProtoMessage()    {}
+func (*GetSchemaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{5}
+}
+
+func (m *GetSchemaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSchemaRequest.Unmarshal(m, b)
+}
+func (m *GetSchemaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSchemaRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSchemaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSchemaRequest.Merge(m, src)
+}
+func (m *GetSchemaRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSchemaRequest.Size(m)
+}
+func (m *GetSchemaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSchemaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSchemaRequest proto.InternalMessageInfo
+
+type GetSchemaResponse struct {
+	Remote               []*PropertySchema `protobuf:"bytes,1,rep,name=remote,proto3" json:"remote,omitempty"`
+	Parameters           []*PropertySchema `protobuf:"bytes,2,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetSchemaResponse) Reset()         { *m = GetSchemaResponse{} }
+func (m *GetSchemaResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSchemaResponse) ProtoMessage()    {}
+func (*GetSchemaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{6}
+}
+
+func (m *GetSchemaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSchemaResponse.Unmarshal(m, b)
+}
+func (m *GetSchemaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSchemaResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSchemaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSchemaResponse.Merge(m, src)
+}
+func (m *GetSchemaResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSchemaResponse.Size(m)
+}
+func (m *GetSchemaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSchemaResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSchemaResponse proto.InternalMessageInfo
+
+func (m *GetSchemaResponse) GetRemote() []*PropertySchema {
+	if m != nil {
+		return m.Remote
+	}
+	return nil
+}
+
+func (m *GetSchemaResponse) GetParameters() []*PropertySchema {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+type HealthRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthRequest) Reset()         { *m = HealthRequest{} }
+func (m *HealthRequest) String() string { return proto.CompactTextString(m) }
+func (*HealthRequest) ProtoMessage()    {}
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{7}
+}
+
+func (m *HealthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthRequest.Unmarshal(m, b)
+}
+func (m *HealthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthRequest.Marshal(b, m, deterministic)
+}
+func (m *HealthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthRequest.Merge(m, src)
+}
+func (m *HealthRequest) XXX_Size() int {
+	return xxx_messageInfo_HealthRequest.Size(m)
+}
+func (m *HealthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthRequest proto.InternalMessageInfo
+
+type HealthResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthResponse) Reset()         { *m = HealthResponse{} }
+func (m *HealthResponse) String() string { return proto.CompactTextString(m) }
+func (*HealthResponse) ProtoMessage()    {}
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{8}
+}
+
+func (m *HealthResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthResponse.Unmarshal(m, b)
+}
+func (m *HealthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthResponse.Marshal(b, m, deterministic)
+}
+func (m *HealthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthResponse.Merge(m, src)
+}
+func (m *HealthResponse) XXX_Size() int {
+	return xxx_messageInfo_HealthResponse.Size(m)
+}
+func (m *HealthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthResponse proto.InternalMessageInfo
+
 type FromURLRequest struct {
 	Url                  string            `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
 	Properties           map[string]string `protobuf:"bytes,2,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
@@ -107,7 +523,7 @@ func (m *FromURLRequest) Reset()         { *m = FromURLRequest{} }
 func (m *FromURLRequest) String() string { return proto.CompactTextString(m) }
 func (*FromURLRequest) ProtoMessage()    {}
 func (*FromURLRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eefc82927d57d89b, []int{2}
+	return fileDescriptor_eefc82927d57d89b, []int{9}
 }
 
 func (m *FromURLRequest) XXX_Unmarshal(b []byte) error {
@@ -153,7 +569,7 @@ func (m *FromURLResponse) Reset()         { *m = FromURLResponse{} }
 func (m *FromURLResponse) String() string { return proto.CompactTextString(m) }
 func (*FromURLResponse) ProtoMessage()    {}
 func (*FromURLResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eefc82927d57d89b, []int{3}
+	return fileDescriptor_eefc82927d57d89b, []int{10}
 }
 
 func (m *FromURLResponse) XXX_Unmarshal(b []byte) error {
@@ -192,7 +608,7 @@ func (m *ToURLRequest) Reset()         { *m = ToURLRequest{} }
 func (m *ToURLRequest) String() string { return proto.CompactTextString(m) }
 func (*ToURLRequest) ProtoMessage()    {}
 func (*ToURLRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eefc82927d57d89b, []int{4}
+	return fileDescriptor_eefc82927d57d89b, []int{11}
 }
 
 func (m *ToURLRequest) XXX_Unmarshal(b []byte) error {
@@ -232,7 +648,7 @@ func (m *ToURLResponse) Reset()         { *m = ToURLResponse{} }
 func (m *ToURLResponse) String() string { return proto.CompactTextString(m) }
 func (*ToURLResponse) ProtoMessage()    {}
 func (*ToURLResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eefc82927d57d89b, []int{5}
+	return fileDescriptor_eefc82927d57d89b, []int{12}
 }
 
 func (m *ToURLResponse) XXX_Unmarshal(b []byte) error {
@@ -278,7 +694,7 @@ func (m *GetParametersRequest) Reset()         { *m = GetParametersRequest{} }
 func (m *GetParametersRequest) String() string { return proto.CompactTextString(m) }
 func (*GetParametersRequest) ProtoMessage()    {}
 func (*GetParametersRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eefc82927d57d89b, []int{6}
+	return fileDescriptor_eefc82927d57d89b, []int{13}
 }
 
 func (m *GetParametersRequest) XXX_Unmarshal(b []byte) error {
@@ -317,7 +733,7 @@ func (m *GetParametersResponse) Reset()         { *m = GetParametersResponse{} }
 func (m *GetParametersResponse) String() string { return proto.CompactTextString(m) }
 func (*GetParametersResponse) ProtoMessage()    {}
 func (*GetParametersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eefc82927d57d89b, []int{7}
+	return fileDescriptor_eefc82927d57d89b, []int{14}
 }
 
 func (m *GetParametersResponse) XXX_Unmarshal(b []byte) error {
@@ -356,7 +772,7 @@ func (m *ValidateRemoteRequest) Reset()         { *m = ValidateRemoteRequest{} }
 func (m *ValidateRemoteRequest) String() string { return proto.CompactTextString(m) }
 func (*ValidateRemoteRequest) ProtoMessage()    {}
 func (*ValidateRemoteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eefc82927d57d89b, []int{8}
+	return fileDescriptor_eefc82927d57d89b, []int{15}
 }
 
 func (m *ValidateRemoteRequest) XXX_Unmarshal(b []byte) error {
@@ -394,7 +810,7 @@ func (m *ValidateRemoteResponse) Reset()         { *m = ValidateRemoteResponse{}
 func (m *ValidateRemoteResponse) String() string { return proto.CompactTextString(m) }
 func (*ValidateRemoteResponse) ProtoMessage()    {}
 func (*ValidateRemoteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eefc82927d57d89b, []int{9}
+	return fileDescriptor_eefc82927d57d89b, []int{16}
 }
 
 func (m *ValidateRemoteResponse) XXX_Unmarshal(b []byte) error {
@@ -426,7 +842,7 @@ func (m *ValidateParametersRequest) Reset()         { *m = ValidateParametersReq
 func (m *ValidateParametersRequest) String() string { return proto.CompactTextString(m) }
 func (*ValidateParametersRequest) ProtoMessage()    {}
 func (*ValidateParametersRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eefc82927d57d89b, []int{10}
+	return fileDescriptor_eefc82927d57d89b, []int{17}
 }
 
 func (m *ValidateParametersRequest) XXX_Unmarshal(b []byte) error {
@@ -464,7 +880,7 @@ func (m *ValidateParametersResponse) Reset()         { *m = ValidateParametersRe
 func (m *ValidateParametersResponse) String() string { return proto.CompactTextString(m) }
 func (*ValidateParametersResponse) ProtoMessage()    {}
 func (*ValidateParametersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eefc82927d57d89b, []int{11}
+	return fileDescriptor_eefc82927d57d89b, []int{18}
 }
 
 func (m *ValidateParametersResponse) XXX_Unmarshal(b []byte) error {
@@ -500,7 +916,7 @@ func (m *Tag) Reset()         { *m = Tag{} }
 func (m *Tag) String() string { return proto.CompactTextString(m) }
 func (*Tag) ProtoMessage()    {}
 func (*Tag) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eefc82927d57d89b, []int{12}
+	return fileDescriptor_eefc82927d57d89b, []int{19}
 }
 
 func (m *Tag) XXX_Unmarshal(b []byte) error {
@@ -585,7 +1001,7 @@ func (m *Commit) Reset()         { *m = Commit{} }
 func (m *Commit) String() string { return proto.CompactTextString(m) }
 func (*Commit) ProtoMessage()    {}
 func (*Commit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eefc82927d57d89b, []int{13}
+	return fileDescriptor_eefc82927d57d89b, []int{20}
 }
 
 func (m *Commit) XXX_Unmarshal(b []byte) error {
@@ -633,7 +1049,7 @@ func (m *GetCommitRequest) Reset()         { *m = GetCommitRequest{} }
 func (m *GetCommitRequest) String() string { return proto.CompactTextString(m) }
 func (*GetCommitRequest) ProtoMessage()    {}
 func (*GetCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eefc82927d57d89b, []int{14}
+	return fileDescriptor_eefc82927d57d89b, []int{21}
 }
 
 func (m *GetCommitRequest) XXX_Unmarshal(b []byte) error {
@@ -689,7 +1105,7 @@ func (m *GetCommitResponse) Reset()         { *m = GetCommitResponse{} }
 func (m *GetCommitResponse) String() string { return proto.CompactTextString(m) }
 func (*GetCommitResponse) ProtoMessage()    {}
 func (*GetCommitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eefc82927d57d89b, []int{15}
+	return fileDescriptor_eefc82927d57d89b, []int{22}
 }
 
 func (m *GetCommitResponse) XXX_Unmarshal(b []byte) error {
@@ -759,6 +1175,8 @@ type ListCommitRequest struct {
 	Remote               *_struct.Struct `protobuf:"bytes,1,opt,name=remote,proto3" json:"remote,omitempty"`
 	Parameters           *_struct.Struct `protobuf:"bytes,2,opt,name=parameters,proto3" json:"parameters,omitempty"`
 	Tags                 []*Tag          `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	PageSize             int32           `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string          `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -768,7 +1186,7 @@ func (m *ListCommitRequest) Reset()         { *m = ListCommitRequest{} }
 func (m *ListCommitRequest) String() string { return proto.CompactTextString(m) }
 func (*ListCommitRequest) ProtoMessage()    {}
 func (*ListCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eefc82927d57d89b, []int{16}
+	return fileDescriptor_eefc82927d57d89b, []int{23}
 }
 
 func (m *ListCommitRequest) XXX_Unmarshal(b []byte) error {
@@ -810,48 +1228,1194 @@ func (m *ListCommitRequest) GetTags() []*Tag {
 	return nil
 }
 
-type ListCommitResponse struct {
-	Commits              []*Commit `protobuf:"bytes,1,rep,name=commits,proto3" json:"commits,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *ListCommitRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListCommitRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListCommitResponse struct {
+	Commits              []*Commit `protobuf:"bytes,1,rep,name=commits,proto3" json:"commits,omitempty"`
+	NextPageToken        string    `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *ListCommitResponse) Reset()         { *m = ListCommitResponse{} }
 func (m *ListCommitResponse) String() string { return proto.CompactTextString(m) }
 func (*ListCommitResponse) ProtoMessage()    {}
 func (*ListCommitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eefc82927d57d89b, []int{17}
+	return fileDescriptor_eefc82927d57d89b, []int{24}
+}
+
+func (m *ListCommitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCommitResponse.Unmarshal(m, b)
+}
+func (m *ListCommitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCommitResponse.Marshal(b, m, deterministic)
+}
+func (m *ListCommitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCommitResponse.Merge(m, src)
+}
+func (m *ListCommitResponse) XXX_Size() int {
+	return xxx_messageInfo_ListCommitResponse.Size(m)
+}
+func (m *ListCommitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCommitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCommitResponse proto.InternalMessageInfo
+
+func (m *ListCommitResponse) GetCommits() []*Commit {
+	if m != nil {
+		return m.Commits
+	}
+	return nil
+}
+
+func (m *ListCommitResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type Operation struct {
+	Id                   string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type                 string          `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	CommitId             string          `protobuf:"bytes,3,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
+	Remote               *_struct.Struct `protobuf:"bytes,4,opt,name=remote,proto3" json:"remote,omitempty"`
+	Parameters           *_struct.Struct `protobuf:"bytes,5,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	Data                 *_struct.Struct `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Operation) Reset()         { *m = Operation{} }
+func (m *Operation) String() string { return proto.CompactTextString(m) }
+func (*Operation) ProtoMessage()    {}
+func (*Operation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{25}
+}
+
+func (m *Operation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Operation.Unmarshal(m, b)
+}
+func (m *Operation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Operation.Marshal(b, m, deterministic)
+}
+func (m *Operation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Operation.Merge(m, src)
+}
+func (m *Operation) XXX_Size() int {
+	return xxx_messageInfo_Operation.Size(m)
+}
+func (m *Operation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Operation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Operation proto.InternalMessageInfo
+
+func (m *Operation) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Operation) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Operation) GetCommitId() string {
+	if m != nil {
+		return m.CommitId
+	}
+	return ""
+}
+
+func (m *Operation) GetRemote() *_struct.Struct {
+	if m != nil {
+		return m.Remote
+	}
+	return nil
+}
+
+func (m *Operation) GetParameters() *_struct.Struct {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+func (m *Operation) GetData() *_struct.Struct {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type Volume struct {
+	Name                 string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Properties           *_struct.Struct `protobuf:"bytes,2,opt,name=properties,proto3" json:"properties,omitempty"`
+	Path                 string          `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	ScratchPath          string          `protobuf:"bytes,4,opt,name=scratch_path,json=scratchPath,proto3" json:"scratch_path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Volume) Reset()         { *m = Volume{} }
+func (m *Volume) String() string { return proto.CompactTextString(m) }
+func (*Volume) ProtoMessage()    {}
+func (*Volume) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{26}
+}
+
+func (m *Volume) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Volume.Unmarshal(m, b)
+}
+func (m *Volume) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Volume.Marshal(b, m, deterministic)
+}
+func (m *Volume) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Volume.Merge(m, src)
+}
+func (m *Volume) XXX_Size() int {
+	return xxx_messageInfo_Volume.Size(m)
+}
+func (m *Volume) XXX_DiscardUnknown() {
+	xxx_messageInfo_Volume.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Volume proto.InternalMessageInfo
+
+func (m *Volume) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Volume) GetProperties() *_struct.Struct {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *Volume) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Volume) GetScratchPath() string {
+	if m != nil {
+		return m.ScratchPath
+	}
+	return ""
+}
+
+type StartOperationRequest struct {
+	Operation            *Operation `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *StartOperationRequest) Reset()         { *m = StartOperationRequest{} }
+func (m *StartOperationRequest) String() string { return proto.CompactTextString(m) }
+func (*StartOperationRequest) ProtoMessage()    {}
+func (*StartOperationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{27}
+}
+
+func (m *StartOperationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartOperationRequest.Unmarshal(m, b)
+}
+func (m *StartOperationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartOperationRequest.Marshal(b, m, deterministic)
+}
+func (m *StartOperationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartOperationRequest.Merge(m, src)
+}
+func (m *StartOperationRequest) XXX_Size() int {
+	return xxx_messageInfo_StartOperationRequest.Size(m)
+}
+func (m *StartOperationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartOperationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartOperationRequest proto.InternalMessageInfo
+
+func (m *StartOperationRequest) GetOperation() *Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+type StartOperationResponse struct {
+	Data                 *_struct.Struct `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *StartOperationResponse) Reset()         { *m = StartOperationResponse{} }
+func (m *StartOperationResponse) String() string { return proto.CompactTextString(m) }
+func (*StartOperationResponse) ProtoMessage()    {}
+func (*StartOperationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{28}
+}
+
+func (m *StartOperationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartOperationResponse.Unmarshal(m, b)
+}
+func (m *StartOperationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartOperationResponse.Marshal(b, m, deterministic)
+}
+func (m *StartOperationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartOperationResponse.Merge(m, src)
+}
+func (m *StartOperationResponse) XXX_Size() int {
+	return xxx_messageInfo_StartOperationResponse.Size(m)
+}
+func (m *StartOperationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartOperationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartOperationResponse proto.InternalMessageInfo
+
+func (m *StartOperationResponse) GetData() *_struct.Struct {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type PushMetadataRequest struct {
+	Operation            *Operation `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Commit               *Commit    `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	IsUpdate             bool       `protobuf:"varint,3,opt,name=is_update,json=isUpdate,proto3" json:"is_update,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *PushMetadataRequest) Reset()         { *m = PushMetadataRequest{} }
+func (m *PushMetadataRequest) String() string { return proto.CompactTextString(m) }
+func (*PushMetadataRequest) ProtoMessage()    {}
+func (*PushMetadataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{29}
+}
+
+func (m *PushMetadataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PushMetadataRequest.Unmarshal(m, b)
+}
+func (m *PushMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PushMetadataRequest.Marshal(b, m, deterministic)
+}
+func (m *PushMetadataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushMetadataRequest.Merge(m, src)
+}
+func (m *PushMetadataRequest) XXX_Size() int {
+	return xxx_messageInfo_PushMetadataRequest.Size(m)
+}
+func (m *PushMetadataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushMetadataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PushMetadataRequest proto.InternalMessageInfo
+
+func (m *PushMetadataRequest) GetOperation() *Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+func (m *PushMetadataRequest) GetCommit() *Commit {
+	if m != nil {
+		return m.Commit
+	}
+	return nil
+}
+
+func (m *PushMetadataRequest) GetIsUpdate() bool {
+	if m != nil {
+		return m.IsUpdate
+	}
+	return false
+}
+
+type PushMetadataResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PushMetadataResponse) Reset()         { *m = PushMetadataResponse{} }
+func (m *PushMetadataResponse) String() string { return proto.CompactTextString(m) }
+func (*PushMetadataResponse) ProtoMessage()    {}
+func (*PushMetadataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{30}
+}
+
+func (m *PushMetadataResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PushMetadataResponse.Unmarshal(m, b)
+}
+func (m *PushMetadataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PushMetadataResponse.Marshal(b, m, deterministic)
+}
+func (m *PushMetadataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushMetadataResponse.Merge(m, src)
+}
+func (m *PushMetadataResponse) XXX_Size() int {
+	return xxx_messageInfo_PushMetadataResponse.Size(m)
+}
+func (m *PushMetadataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushMetadataResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PushMetadataResponse proto.InternalMessageInfo
+
+type SyncVolumeRequest struct {
+	Operation            *Operation `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Volume               *Volume    `protobuf:"bytes,2,opt,name=volume,proto3" json:"volume,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SyncVolumeRequest) Reset()         { *m = SyncVolumeRequest{} }
+func (m *SyncVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*SyncVolumeRequest) ProtoMessage()    {}
+func (*SyncVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{31}
+}
+
+func (m *SyncVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncVolumeRequest.Unmarshal(m, b)
+}
+func (m *SyncVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncVolumeRequest.Marshal(b, m, deterministic)
+}
+func (m *SyncVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncVolumeRequest.Merge(m, src)
+}
+func (m *SyncVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_SyncVolumeRequest.Size(m)
+}
+func (m *SyncVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncVolumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncVolumeRequest proto.InternalMessageInfo
+
+func (m *SyncVolumeRequest) GetOperation() *Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+func (m *SyncVolumeRequest) GetVolume() *Volume {
+	if m != nil {
+		return m.Volume
+	}
+	return nil
+}
+
+type SyncVolumeResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncVolumeResponse) Reset()         { *m = SyncVolumeResponse{} }
+func (m *SyncVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*SyncVolumeResponse) ProtoMessage()    {}
+func (*SyncVolumeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{32}
+}
+
+func (m *SyncVolumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncVolumeResponse.Unmarshal(m, b)
+}
+func (m *SyncVolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncVolumeResponse.Marshal(b, m, deterministic)
+}
+func (m *SyncVolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncVolumeResponse.Merge(m, src)
+}
+func (m *SyncVolumeResponse) XXX_Size() int {
+	return xxx_messageInfo_SyncVolumeResponse.Size(m)
+}
+func (m *SyncVolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncVolumeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncVolumeResponse proto.InternalMessageInfo
+
+type EndOperationRequest struct {
+	Operation            *Operation `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *EndOperationRequest) Reset()         { *m = EndOperationRequest{} }
+func (m *EndOperationRequest) String() string { return proto.CompactTextString(m) }
+func (*EndOperationRequest) ProtoMessage()    {}
+func (*EndOperationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{33}
+}
+
+func (m *EndOperationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndOperationRequest.Unmarshal(m, b)
+}
+func (m *EndOperationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndOperationRequest.Marshal(b, m, deterministic)
+}
+func (m *EndOperationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndOperationRequest.Merge(m, src)
+}
+func (m *EndOperationRequest) XXX_Size() int {
+	return xxx_messageInfo_EndOperationRequest.Size(m)
+}
+func (m *EndOperationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndOperationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndOperationRequest proto.InternalMessageInfo
+
+func (m *EndOperationRequest) GetOperation() *Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+type EndOperationResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EndOperationResponse) Reset()         { *m = EndOperationResponse{} }
+func (m *EndOperationResponse) String() string { return proto.CompactTextString(m) }
+func (*EndOperationResponse) ProtoMessage()    {}
+func (*EndOperationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{34}
+}
+
+func (m *EndOperationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndOperationResponse.Unmarshal(m, b)
+}
+func (m *EndOperationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndOperationResponse.Marshal(b, m, deterministic)
+}
+func (m *EndOperationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndOperationResponse.Merge(m, src)
+}
+func (m *EndOperationResponse) XXX_Size() int {
+	return xxx_messageInfo_EndOperationResponse.Size(m)
+}
+func (m *EndOperationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndOperationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndOperationResponse proto.InternalMessageInfo
+
+type FailOperationRequest struct {
+	Operation            *Operation `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Reason               string     `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *FailOperationRequest) Reset()         { *m = FailOperationRequest{} }
+func (m *FailOperationRequest) String() string { return proto.CompactTextString(m) }
+func (*FailOperationRequest) ProtoMessage()    {}
+func (*FailOperationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{35}
+}
+
+func (m *FailOperationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FailOperationRequest.Unmarshal(m, b)
+}
+func (m *FailOperationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FailOperationRequest.Marshal(b, m, deterministic)
+}
+func (m *FailOperationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FailOperationRequest.Merge(m, src)
+}
+func (m *FailOperationRequest) XXX_Size() int {
+	return xxx_messageInfo_FailOperationRequest.Size(m)
+}
+func (m *FailOperationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FailOperationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FailOperationRequest proto.InternalMessageInfo
+
+func (m *FailOperationRequest) GetOperation() *Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+func (m *FailOperationRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type FailOperationResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FailOperationResponse) Reset()         { *m = FailOperationResponse{} }
+func (m *FailOperationResponse) String() string { return proto.CompactTextString(m) }
+func (*FailOperationResponse) ProtoMessage()    {}
+func (*FailOperationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{36}
+}
+
+func (m *FailOperationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FailOperationResponse.Unmarshal(m, b)
+}
+func (m *FailOperationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FailOperationResponse.Marshal(b, m, deterministic)
+}
+func (m *FailOperationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FailOperationResponse.Merge(m, src)
+}
+func (m *FailOperationResponse) XXX_Size() int {
+	return xxx_messageInfo_FailOperationResponse.Size(m)
+}
+func (m *FailOperationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FailOperationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FailOperationResponse proto.InternalMessageInfo
+
+// Volume data is transferred as a sequence of chunks, each with a CRC-32C checksum of its data. The operation and
+// volume are only present in the first chunk of an upload, whose offset is non-zero when resuming a transfer.
+type VolumeChunk struct {
+	Operation            *Operation `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Volume               string     `protobuf:"bytes,2,opt,name=volume,proto3" json:"volume,omitempty"`
+	Offset               int64      `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data                 []byte     `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	Checksum             uint32     `protobuf:"varint,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *VolumeChunk) Reset()         { *m = VolumeChunk{} }
+func (m *VolumeChunk) String() string { return proto.CompactTextString(m) }
+func (*VolumeChunk) ProtoMessage()    {}
+func (*VolumeChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{37}
+}
+
+func (m *VolumeChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeChunk.Unmarshal(m, b)
+}
+func (m *VolumeChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeChunk.Marshal(b, m, deterministic)
+}
+func (m *VolumeChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeChunk.Merge(m, src)
+}
+func (m *VolumeChunk) XXX_Size() int {
+	return xxx_messageInfo_VolumeChunk.Size(m)
+}
+func (m *VolumeChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeChunk proto.InternalMessageInfo
+
+func (m *VolumeChunk) GetOperation() *Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+func (m *VolumeChunk) GetVolume() string {
+	if m != nil {
+		return m.Volume
+	}
+	return ""
+}
+
+func (m *VolumeChunk) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *VolumeChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *VolumeChunk) GetChecksum() uint32 {
+	if m != nil {
+		return m.Checksum
+	}
+	return 0
+}
+
+type UploadVolumeResponse struct {
+	Offset               int64    `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadVolumeResponse) Reset()         { *m = UploadVolumeResponse{} }
+func (m *UploadVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*UploadVolumeResponse) ProtoMessage()    {}
+func (*UploadVolumeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{38}
+}
+
+func (m *UploadVolumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadVolumeResponse.Unmarshal(m, b)
+}
+func (m *UploadVolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadVolumeResponse.Marshal(b, m, deterministic)
+}
+func (m *UploadVolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadVolumeResponse.Merge(m, src)
+}
+func (m *UploadVolumeResponse) XXX_Size() int {
+	return xxx_messageInfo_UploadVolumeResponse.Size(m)
+}
+func (m *UploadVolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadVolumeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadVolumeResponse proto.InternalMessageInfo
+
+func (m *UploadVolumeResponse) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type DownloadVolumeRequest struct {
+	Operation            *Operation `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Volume               string     `protobuf:"bytes,2,opt,name=volume,proto3" json:"volume,omitempty"`
+	Offset               int64      `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *DownloadVolumeRequest) Reset()         { *m = DownloadVolumeRequest{} }
+func (m *DownloadVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*DownloadVolumeRequest) ProtoMessage()    {}
+func (*DownloadVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{39}
+}
+
+func (m *DownloadVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DownloadVolumeRequest.Unmarshal(m, b)
+}
+func (m *DownloadVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DownloadVolumeRequest.Marshal(b, m, deterministic)
+}
+func (m *DownloadVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DownloadVolumeRequest.Merge(m, src)
+}
+func (m *DownloadVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_DownloadVolumeRequest.Size(m)
+}
+func (m *DownloadVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DownloadVolumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DownloadVolumeRequest proto.InternalMessageInfo
+
+func (m *DownloadVolumeRequest) GetOperation() *Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+func (m *DownloadVolumeRequest) GetVolume() string {
+	if m != nil {
+		return m.Volume
+	}
+	return ""
+}
+
+func (m *DownloadVolumeRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type GetVolumeOffsetRequest struct {
+	Operation            *Operation `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Volume               string     `protobuf:"bytes,2,opt,name=volume,proto3" json:"volume,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetVolumeOffsetRequest) Reset()         { *m = GetVolumeOffsetRequest{} }
+func (m *GetVolumeOffsetRequest) String() string { return proto.CompactTextString(m) }
+func (*GetVolumeOffsetRequest) ProtoMessage()    {}
+func (*GetVolumeOffsetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{40}
+}
+
+func (m *GetVolumeOffsetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetVolumeOffsetRequest.Unmarshal(m, b)
+}
+func (m *GetVolumeOffsetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetVolumeOffsetRequest.Marshal(b, m, deterministic)
+}
+func (m *GetVolumeOffsetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetVolumeOffsetRequest.Merge(m, src)
+}
+func (m *GetVolumeOffsetRequest) XXX_Size() int {
+	return xxx_messageInfo_GetVolumeOffsetRequest.Size(m)
+}
+func (m *GetVolumeOffsetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetVolumeOffsetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetVolumeOffsetRequest proto.InternalMessageInfo
+
+func (m *GetVolumeOffsetRequest) GetOperation() *Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+func (m *GetVolumeOffsetRequest) GetVolume() string {
+	if m != nil {
+		return m.Volume
+	}
+	return ""
+}
+
+type GetVolumeOffsetResponse struct {
+	Offset               int64    `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetVolumeOffsetResponse) Reset()         { *m = GetVolumeOffsetResponse{} }
+func (m *GetVolumeOffsetResponse) String() string { return proto.CompactTextString(m) }
+func (*GetVolumeOffsetResponse) ProtoMessage()    {}
+func (*GetVolumeOffsetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{41}
+}
+
+func (m *GetVolumeOffsetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetVolumeOffsetResponse.Unmarshal(m, b)
+}
+func (m *GetVolumeOffsetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetVolumeOffsetResponse.Marshal(b, m, deterministic)
+}
+func (m *GetVolumeOffsetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetVolumeOffsetResponse.Merge(m, src)
+}
+func (m *GetVolumeOffsetResponse) XXX_Size() int {
+	return xxx_messageInfo_GetVolumeOffsetResponse.Size(m)
+}
+func (m *GetVolumeOffsetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetVolumeOffsetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetVolumeOffsetResponse proto.InternalMessageInfo
+
+func (m *GetVolumeOffsetResponse) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type ReportProgressRequest struct {
+	CallId               string   `protobuf:"bytes,1,opt,name=call_id,json=callId,proto3" json:"call_id,omitempty"`
+	Phase                string   `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"`
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	BytesDone            int64    `protobuf:"varint,4,opt,name=bytes_done,json=bytesDone,proto3" json:"bytes_done,omitempty"`
+	BytesTotal           int64    `protobuf:"varint,5,opt,name=bytes_total,json=bytesTotal,proto3" json:"bytes_total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReportProgressRequest) Reset()         { *m = ReportProgressRequest{} }
+func (m *ReportProgressRequest) String() string { return proto.CompactTextString(m) }
+func (*ReportProgressRequest) ProtoMessage()    {}
+func (*ReportProgressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{42}
+}
+
+func (m *ReportProgressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportProgressRequest.Unmarshal(m, b)
+}
+func (m *ReportProgressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportProgressRequest.Marshal(b, m, deterministic)
+}
+func (m *ReportProgressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportProgressRequest.Merge(m, src)
+}
+func (m *ReportProgressRequest) XXX_Size() int {
+	return xxx_messageInfo_ReportProgressRequest.Size(m)
+}
+func (m *ReportProgressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportProgressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportProgressRequest proto.InternalMessageInfo
+
+func (m *ReportProgressRequest) GetCallId() string {
+	if m != nil {
+		return m.CallId
+	}
+	return ""
+}
+
+func (m *ReportProgressRequest) GetPhase() string {
+	if m != nil {
+		return m.Phase
+	}
+	return ""
 }
 
-func (m *ListCommitResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListCommitResponse.Unmarshal(m, b)
+func (m *ReportProgressRequest) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
 }
-func (m *ListCommitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListCommitResponse.Marshal(b, m, deterministic)
+
+func (m *ReportProgressRequest) GetBytesDone() int64 {
+	if m != nil {
+		return m.BytesDone
+	}
+	return 0
 }
-func (m *ListCommitResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListCommitResponse.Merge(m, src)
+
+func (m *ReportProgressRequest) GetBytesTotal() int64 {
+	if m != nil {
+		return m.BytesTotal
+	}
+	return 0
 }
-func (m *ListCommitResponse) XXX_Size() int {
-	return xxx_messageInfo_ListCommitResponse.Size(m)
+
+type ReportProgressResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *ListCommitResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListCommitResponse.DiscardUnknown(m)
+
+func (m *ReportProgressResponse) Reset()         { *m = ReportProgressResponse{} }
+func (m *ReportProgressResponse) String() string { return proto.CompactTextString(m) }
+func (*ReportProgressResponse) ProtoMessage()    {}
+func (*ReportProgressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{43}
 }
 
-var xxx_messageInfo_ListCommitResponse proto.InternalMessageInfo
+func (m *ReportProgressResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportProgressResponse.Unmarshal(m, b)
+}
+func (m *ReportProgressResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportProgressResponse.Marshal(b, m, deterministic)
+}
+func (m *ReportProgressResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportProgressResponse.Merge(m, src)
+}
+func (m *ReportProgressResponse) XXX_Size() int {
+	return xxx_messageInfo_ReportProgressResponse.Size(m)
+}
+func (m *ReportProgressResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportProgressResponse.DiscardUnknown(m)
+}
 
-func (m *ListCommitResponse) GetCommits() []*Commit {
+var xxx_messageInfo_ReportProgressResponse proto.InternalMessageInfo
+
+type PromptRequest struct {
+	CallId               string             `protobuf:"bytes,1,opt,name=call_id,json=callId,proto3" json:"call_id,omitempty"`
+	Kind                 PromptRequest_Kind `protobuf:"varint,2,opt,name=kind,proto3,enum=remote.PromptRequest_Kind" json:"kind,omitempty"`
+	Message              string             `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	DefaultText          string             `protobuf:"bytes,4,opt,name=default_text,json=defaultText,proto3" json:"default_text,omitempty"`
+	DefaultConfirm       bool               `protobuf:"varint,5,opt,name=default_confirm,json=defaultConfirm,proto3" json:"default_confirm,omitempty"`
+	Choices              []string           `protobuf:"bytes,6,rep,name=choices,proto3" json:"choices,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *PromptRequest) Reset()         { *m = PromptRequest{} }
+func (m *PromptRequest) String() string { return proto.CompactTextString(m) }
+func (*PromptRequest) ProtoMessage()    {}
+func (*PromptRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{44}
+}
+
+func (m *PromptRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PromptRequest.Unmarshal(m, b)
+}
+func (m *PromptRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PromptRequest.Marshal(b, m, deterministic)
+}
+func (m *PromptRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PromptRequest.Merge(m, src)
+}
+func (m *PromptRequest) XXX_Size() int {
+	return xxx_messageInfo_PromptRequest.Size(m)
+}
+func (m *PromptRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PromptRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PromptRequest proto.InternalMessageInfo
+
+func (m *PromptRequest) GetCallId() string {
 	if m != nil {
-		return m.Commits
+		return m.CallId
+	}
+	return ""
+}
+
+func (m *PromptRequest) GetKind() PromptRequest_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return PromptRequest_TEXT
+}
+
+func (m *PromptRequest) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *PromptRequest) GetDefaultText() string {
+	if m != nil {
+		return m.DefaultText
+	}
+	return ""
+}
+
+func (m *PromptRequest) GetDefaultConfirm() bool {
+	if m != nil {
+		return m.DefaultConfirm
+	}
+	return false
+}
+
+func (m *PromptRequest) GetChoices() []string {
+	if m != nil {
+		return m.Choices
+	}
+	return nil
+}
+
+type PromptResponse struct {
+	Text                 string   `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Confirmed            bool     `protobuf:"varint,2,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PromptResponse) Reset()         { *m = PromptResponse{} }
+func (m *PromptResponse) String() string { return proto.CompactTextString(m) }
+func (*PromptResponse) ProtoMessage()    {}
+func (*PromptResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{45}
+}
+
+func (m *PromptResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PromptResponse.Unmarshal(m, b)
+}
+func (m *PromptResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PromptResponse.Marshal(b, m, deterministic)
+}
+func (m *PromptResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PromptResponse.Merge(m, src)
+}
+func (m *PromptResponse) XXX_Size() int {
+	return xxx_messageInfo_PromptResponse.Size(m)
+}
+func (m *PromptResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PromptResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PromptResponse proto.InternalMessageInfo
+
+func (m *PromptResponse) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+func (m *PromptResponse) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
+	}
+	return false
+}
+
+// Attached to the status of failed calls, identifying the kind of error returned by the remote.
+type ErrorDetail struct {
+	Kind                 string            `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Message              string            `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Violations           []*FieldViolation `protobuf:"bytes,3,rep,name=violations,proto3" json:"violations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ErrorDetail) Reset()         { *m = ErrorDetail{} }
+func (m *ErrorDetail) String() string { return proto.CompactTextString(m) }
+func (*ErrorDetail) ProtoMessage()    {}
+func (*ErrorDetail) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{46}
+}
+
+func (m *ErrorDetail) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ErrorDetail.Unmarshal(m, b)
+}
+func (m *ErrorDetail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ErrorDetail.Marshal(b, m, deterministic)
+}
+func (m *ErrorDetail) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErrorDetail.Merge(m, src)
+}
+func (m *ErrorDetail) XXX_Size() int {
+	return xxx_messageInfo_ErrorDetail.Size(m)
+}
+func (m *ErrorDetail) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErrorDetail.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ErrorDetail proto.InternalMessageInfo
+
+func (m *ErrorDetail) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ErrorDetail) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ErrorDetail) GetViolations() []*FieldViolation {
+	if m != nil {
+		return m.Violations
 	}
 	return nil
 }
 
+type FieldViolation struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FieldViolation) Reset()         { *m = FieldViolation{} }
+func (m *FieldViolation) String() string { return proto.CompactTextString(m) }
+func (*FieldViolation) ProtoMessage()    {}
+func (*FieldViolation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{47}
+}
+
+func (m *FieldViolation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FieldViolation.Unmarshal(m, b)
+}
+func (m *FieldViolation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FieldViolation.Marshal(b, m, deterministic)
+}
+func (m *FieldViolation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldViolation.Merge(m, src)
+}
+func (m *FieldViolation) XXX_Size() int {
+	return xxx_messageInfo_FieldViolation.Size(m)
+}
+func (m *FieldViolation) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldViolation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FieldViolation proto.InternalMessageInfo
+
+func (m *FieldViolation) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FieldViolation) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
 func init() {
+	proto.RegisterEnum("remote.PromptRequest_Kind", PromptRequest_Kind_name, PromptRequest_Kind_value)
 	proto.RegisterType((*GetTypeRequest)(nil), "remote.GetTypeRequest")
 	proto.RegisterType((*GetTypeResponse)(nil), "remote.GetTypeResponse")
+	proto.RegisterType((*GetCapabilitiesRequest)(nil), "remote.GetCapabilitiesRequest")
+	proto.RegisterType((*GetCapabilitiesResponse)(nil), "remote.GetCapabilitiesResponse")
+	proto.RegisterType((*PropertySchema)(nil), "remote.PropertySchema")
+	proto.RegisterType((*GetSchemaRequest)(nil), "remote.GetSchemaRequest")
+	proto.RegisterType((*GetSchemaResponse)(nil), "remote.GetSchemaResponse")
+	proto.RegisterType((*HealthRequest)(nil), "remote.HealthRequest")
+	proto.RegisterType((*HealthResponse)(nil), "remote.HealthResponse")
 	proto.RegisterType((*FromURLRequest)(nil), "remote.FromURLRequest")
 	proto.RegisterMapType((map[string]string)(nil), "remote.FromURLRequest.PropertiesEntry")
 	proto.RegisterType((*FromURLResponse)(nil), "remote.FromURLResponse")
@@ -870,71 +2434,174 @@ func init() {
 	proto.RegisterType((*GetCommitResponse)(nil), "remote.GetCommitResponse")
 	proto.RegisterType((*ListCommitRequest)(nil), "remote.ListCommitRequest")
 	proto.RegisterType((*ListCommitResponse)(nil), "remote.ListCommitResponse")
+	proto.RegisterType((*Operation)(nil), "remote.Operation")
+	proto.RegisterType((*Volume)(nil), "remote.Volume")
+	proto.RegisterType((*StartOperationRequest)(nil), "remote.StartOperationRequest")
+	proto.RegisterType((*StartOperationResponse)(nil), "remote.StartOperationResponse")
+	proto.RegisterType((*PushMetadataRequest)(nil), "remote.PushMetadataRequest")
+	proto.RegisterType((*PushMetadataResponse)(nil), "remote.PushMetadataResponse")
+	proto.RegisterType((*SyncVolumeRequest)(nil), "remote.SyncVolumeRequest")
+	proto.RegisterType((*SyncVolumeResponse)(nil), "remote.SyncVolumeResponse")
+	proto.RegisterType((*EndOperationRequest)(nil), "remote.EndOperationRequest")
+	proto.RegisterType((*EndOperationResponse)(nil), "remote.EndOperationResponse")
+	proto.RegisterType((*FailOperationRequest)(nil), "remote.FailOperationRequest")
+	proto.RegisterType((*FailOperationResponse)(nil), "remote.FailOperationResponse")
+	proto.RegisterType((*VolumeChunk)(nil), "remote.VolumeChunk")
+	proto.RegisterType((*UploadVolumeResponse)(nil), "remote.UploadVolumeResponse")
+	proto.RegisterType((*DownloadVolumeRequest)(nil), "remote.DownloadVolumeRequest")
+	proto.RegisterType((*GetVolumeOffsetRequest)(nil), "remote.GetVolumeOffsetRequest")
+	proto.RegisterType((*GetVolumeOffsetResponse)(nil), "remote.GetVolumeOffsetResponse")
+	proto.RegisterType((*ReportProgressRequest)(nil), "remote.ReportProgressRequest")
+	proto.RegisterType((*ReportProgressResponse)(nil), "remote.ReportProgressResponse")
+	proto.RegisterType((*PromptRequest)(nil), "remote.PromptRequest")
+	proto.RegisterType((*PromptResponse)(nil), "remote.PromptResponse")
+	proto.RegisterType((*ErrorDetail)(nil), "remote.ErrorDetail")
+	proto.RegisterType((*FieldViolation)(nil), "remote.FieldViolation")
 }
 
 func init() { proto.RegisterFile("remote.proto", fileDescriptor_eefc82927d57d89b) }
 
 var fileDescriptor_eefc82927d57d89b = []byte{
-	// 697 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x55, 0x4d, 0x4f, 0xdb, 0x4c,
-	0x10, 0xc6, 0x09, 0x24, 0x64, 0x1c, 0x02, 0xac, 0xf8, 0x30, 0x7e, 0x79, 0x0b, 0x6c, 0x45, 0xc5,
-	0x29, 0x48, 0xa1, 0x52, 0x2b, 0xa4, 0x7e, 0x88, 0x16, 0x42, 0x25, 0xd4, 0xa6, 0x26, 0xe5, 0xd2,
-	0x03, 0x32, 0x64, 0xb1, 0x2c, 0x9c, 0xd8, 0xb5, 0xd7, 0x95, 0xf2, 0x53, 0x7a, 0xe9, 0xb5, 0xea,
-	0x5f, 0xe8, 0xaf, 0xab, 0xbc, 0x3b, 0x76, 0xfc, 0x45, 0x69, 0x23, 0x55, 0xbd, 0xd9, 0x33, 0xf3,
-	0x3c, 0x33, 0xf3, 0xcc, 0xee, 0x2c, 0x34, 0x7d, 0x36, 0x74, 0x39, 0x6b, 0x7b, 0xbe, 0xcb, 0x5d,
-	0x52, 0x93, 0x7f, 0xfa, 0xa6, 0xe5, 0xba, 0x96, 0xc3, 0xf6, 0x85, 0xf5, 0x2a, 0xbc, 0xd9, 0x0f,
-	0xb8, 0x1f, 0x5e, 0x73, 0x19, 0x45, 0x97, 0xa0, 0xd5, 0x65, 0xbc, 0x3f, 0xf6, 0x98, 0xc1, 0x3e,
-	0x85, 0x2c, 0xe0, 0x74, 0x17, 0x16, 0x13, 0x4b, 0xe0, 0xb9, 0xa3, 0x80, 0x11, 0x02, 0xb3, 0x7c,
-	0xec, 0x31, 0x4d, 0xd9, 0x56, 0xf6, 0x1a, 0x86, 0xf8, 0xa6, 0xdf, 0x15, 0x68, 0x9d, 0xf8, 0xee,
-	0xf0, 0x83, 0x71, 0x86, 0x48, 0xb2, 0x04, 0xd5, 0xd0, 0x77, 0x30, 0x2a, 0xfa, 0x24, 0x27, 0x00,
-	0x9e, 0xef, 0x7a, 0xcc, 0xe7, 0x36, 0x0b, 0xb4, 0xca, 0x76, 0x75, 0x4f, 0xed, 0x3c, 0x6a, 0x63,
-	0x99, 0x59, 0x74, 0xbb, 0x97, 0x04, 0x1e, 0x8f, 0xb8, 0x3f, 0x36, 0x52, 0x48, 0xfd, 0x19, 0x2c,
-	0xe6, 0xdc, 0x51, 0xb2, 0x5b, 0x36, 0x8e, 0x93, 0xdd, 0xb2, 0x31, 0x59, 0x81, 0xb9, 0xcf, 0xa6,
-	0x13, 0x32, 0xad, 0x22, 0x6c, 0xf2, 0xe7, 0xb0, 0xf2, 0x54, 0xa1, 0x47, 0xb0, 0x98, 0x24, 0xc3,
-	0x96, 0xf6, 0x01, 0xf5, 0x11, 0x0c, 0x6a, 0x67, 0xbd, 0x2d, 0x65, 0x6a, 0xc7, 0x32, 0xb5, 0xcf,
-	0x85, 0x4c, 0x06, 0x86, 0xd1, 0x17, 0xd0, 0xec, 0xbb, 0xa9, 0x66, 0xff, 0x98, 0xe0, 0x9b, 0x02,
-	0x0b, 0xc8, 0x80, 0x35, 0x14, 0xf5, 0x3a, 0x2e, 0xd1, 0x6b, 0x37, 0xd6, 0x2b, 0x03, 0xfe, 0x9b,
-	0x72, 0x75, 0x61, 0xa5, 0xcb, 0x78, 0xcf, 0xf4, 0xcd, 0x21, 0xe3, 0xcc, 0x0f, 0xa6, 0x6e, 0xb9,
-	0x07, 0xab, 0x39, 0x22, 0xec, 0xfc, 0x09, 0x80, 0x97, 0x58, 0xef, 0x63, 0x4b, 0x85, 0xd2, 0x53,
-	0x58, 0xbd, 0x30, 0x1d, 0x7b, 0x60, 0x72, 0x66, 0x88, 0x1c, 0x53, 0xd7, 0xa6, 0xc1, 0x5a, 0x9e,
-	0x49, 0x16, 0x47, 0xfb, 0xb0, 0x11, 0x7b, 0x8a, 0x1a, 0x4c, 0x5d, 0xf9, 0x26, 0xe8, 0x65, 0xac,
-	0x98, 0xf3, 0x06, 0xaa, 0x7d, 0xd3, 0x2a, 0x99, 0xd2, 0x16, 0x80, 0x18, 0xcc, 0xe5, 0x28, 0x74,
-	0x1c, 0x31, 0xaa, 0xf9, 0xd3, 0x19, 0xa3, 0x21, 0x6c, 0x6f, 0x43, 0xc7, 0x21, 0x0f, 0xa1, 0x29,
-	0x03, 0x02, 0xee, 0xdb, 0x23, 0x4b, 0xab, 0x46, 0xd8, 0xd3, 0x19, 0x43, 0x15, 0xd6, 0x73, 0x61,
-	0x3c, 0xaa, 0xe3, 0xac, 0xe9, 0x7b, 0xa8, 0xbd, 0x72, 0x87, 0x43, 0x9b, 0x93, 0x16, 0x54, 0xec,
-	0x01, 0x66, 0xaa, 0xd8, 0x03, 0xd1, 0x58, 0xfa, 0xe8, 0xdd, 0xd3, 0x58, 0x12, 0x4a, 0xbf, 0x28,
-	0xb0, 0xd4, 0x65, 0x5c, 0xd2, 0x4e, 0x3b, 0x8e, 0x9c, 0xae, 0x95, 0xdf, 0xd6, 0x95, 0xfc, 0x07,
-	0x8d, 0x6b, 0x91, 0xfa, 0xd2, 0x1e, 0xc8, 0xe6, 0x8d, 0x79, 0x69, 0x78, 0x33, 0xa0, 0x21, 0x2c,
-	0xa7, 0x4a, 0xc3, 0xc3, 0xb7, 0x03, 0x2a, 0x22, 0x84, 0xa6, 0x0a, 0x6a, 0x0a, 0xd2, 0x28, 0x44,
-	0x3d, 0x80, 0x26, 0x86, 0x4c, 0xae, 0x88, 0xda, 0x69, 0xc5, 0x37, 0x51, 0x12, 0x46, 0x22, 0xcb,
-	0xa8, 0x8b, 0x28, 0xe8, 0x68, 0x1e, 0x6a, 0xf2, 0x97, 0x7e, 0x55, 0x60, 0xf9, 0xcc, 0x0e, 0xfe,
-	0x99, 0x26, 0x5b, 0x30, 0xcb, 0x4d, 0x2b, 0xd0, 0xaa, 0x62, 0x81, 0xa8, 0xc9, 0x02, 0x31, 0x2d,
-	0x43, 0x38, 0xe8, 0x73, 0x20, 0xe9, 0xfa, 0x50, 0x98, 0x3d, 0xa8, 0xcb, 0x06, 0xa2, 0x83, 0x5d,
-	0x2d, 0x36, 0x6c, 0xc4, 0xee, 0xce, 0x8f, 0x59, 0xa8, 0xc9, 0x5b, 0x43, 0x0e, 0xa1, 0x8e, 0xcf,
-	0x05, 0x59, 0x8b, 0xc3, 0xb3, 0x2f, 0x8a, 0xbe, 0x5e, 0xb0, 0x63, 0xc2, 0x43, 0xa8, 0xe3, 0x5e,
-	0x9e, 0x60, 0xb3, 0xaf, 0xc2, 0x04, 0x9b, 0x5f, 0xe0, 0x8f, 0x61, 0x4e, 0x2c, 0x44, 0xb2, 0x92,
-	0xdb, 0x8f, 0x12, 0xb7, 0x5a, 0xba, 0x35, 0xc9, 0x19, 0x2c, 0x64, 0x36, 0x12, 0xd9, 0x4c, 0xd5,
-	0x56, 0xb8, 0xed, 0xfa, 0xff, 0x77, 0x78, 0x91, 0xed, 0x1d, 0xb4, 0xb2, 0x3b, 0x84, 0x24, 0x80,
-	0xd2, 0x2d, 0xa5, 0x3f, 0xb8, 0xcb, 0x8d, 0x84, 0x1f, 0x81, 0x14, 0x97, 0x04, 0xd9, 0xc9, 0xa3,
-	0x8a, 0x85, 0xd2, 0x5f, 0x85, 0x20, 0xf9, 0x6b, 0x50, 0x27, 0x43, 0x0f, 0xc8, 0x46, 0x0c, 0x29,
-	0x9c, 0x54, 0x5d, 0x2f, 0x73, 0x21, 0xcb, 0x4b, 0x68, 0x24, 0x57, 0x8a, 0x68, 0x29, 0x7d, 0xb2,
-	0x14, 0x1b, 0x25, 0x1e, 0xc9, 0x70, 0x55, 0x13, 0x47, 0xf7, 0xe0, 0x67, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x5c, 0x51, 0x20, 0xa4, 0xaf, 0x08, 0x00, 0x00,
+	// 1949 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xdd, 0x6e, 0xdb, 0xc8,
+	0x15, 0x0e, 0x25, 0x59, 0xb2, 0x8e, 0x64, 0xd9, 0x9e, 0xd8, 0x32, 0xc3, 0x75, 0xec, 0x84, 0x8b,
+	0x4d, 0x03, 0xb4, 0x75, 0x52, 0x6f, 0xbb, 0x2d, 0x82, 0xfe, 0x21, 0x8a, 0xfc, 0x83, 0x66, 0xd7,
+	0x2e, 0xad, 0x04, 0x05, 0x7a, 0x21, 0x8c, 0xc5, 0x91, 0x44, 0x98, 0x22, 0xb9, 0x9c, 0x61, 0x1a,
+	0x6f, 0x1f, 0xa1, 0x05, 0xda, 0xdb, 0xde, 0x14, 0x45, 0x6f, 0x8a, 0xbe, 0x49, 0x9f, 0x61, 0x6f,
+	0xfb, 0x22, 0xc5, 0xfc, 0xf0, 0x57, 0x94, 0xed, 0x28, 0x2d, 0x7a, 0xc7, 0xf9, 0xce, 0x99, 0x73,
+	0xce, 0x7c, 0x73, 0x38, 0x73, 0xce, 0x40, 0x3b, 0x24, 0x33, 0x9f, 0x91, 0x83, 0x20, 0xf4, 0x99,
+	0x8f, 0xea, 0x72, 0x64, 0xec, 0x4e, 0x7c, 0x7f, 0xe2, 0x92, 0x67, 0x02, 0xbd, 0x8c, 0xc6, 0xcf,
+	0x28, 0x0b, 0xa3, 0x11, 0x93, 0x5a, 0xe6, 0x06, 0x74, 0x8e, 0x09, 0x1b, 0x5c, 0x07, 0xc4, 0x22,
+	0x5f, 0x47, 0x84, 0x32, 0xf3, 0x33, 0x58, 0x4f, 0x10, 0x1a, 0xf8, 0x1e, 0x25, 0x08, 0x41, 0x8d,
+	0x5d, 0x07, 0x44, 0xd7, 0x1e, 0x69, 0x4f, 0x9b, 0x96, 0xf8, 0x36, 0x75, 0xe8, 0x1e, 0x13, 0xd6,
+	0xc3, 0x01, 0xbe, 0x74, 0x5c, 0x87, 0x39, 0x84, 0xc6, 0x06, 0xfe, 0x55, 0x81, 0x9d, 0x39, 0x91,
+	0xb2, 0x64, 0x42, 0x7b, 0x84, 0xbd, 0x11, 0x71, 0x5d, 0xcc, 0x1c, 0xdf, 0x13, 0x16, 0x57, 0xad,
+	0x1c, 0x86, 0xf6, 0x00, 0x02, 0x3c, 0x71, 0x3c, 0xa9, 0x51, 0x11, 0x1a, 0x19, 0x04, 0xed, 0x42,
+	0x93, 0xb2, 0x90, 0xe0, 0x99, 0xe3, 0x4d, 0xf4, 0xaa, 0x10, 0xa7, 0x00, 0xfa, 0x14, 0xd6, 0x18,
+	0x9e, 0x0c, 0xc7, 0x8e, 0xcb, 0x48, 0xc8, 0x35, 0x6a, 0xd2, 0x05, 0xc3, 0x93, 0xa3, 0x18, 0xe3,
+	0x2e, 0xfc, 0x80, 0x84, 0xc2, 0x1e, 0xd5, 0x57, 0xa4, 0x8b, 0x14, 0x41, 0xdf, 0x81, 0xf5, 0x77,
+	0xbe, 0x1b, 0xcd, 0xc8, 0x90, 0x85, 0xd8, 0xa3, 0x63, 0x12, 0xea, 0x75, 0xa1, 0xd4, 0x91, 0xf0,
+	0x40, 0xa1, 0xe8, 0xfb, 0x80, 0x42, 0x42, 0xa3, 0x19, 0xbe, 0x74, 0x33, 0xba, 0x0d, 0xa1, 0xbb,
+	0x99, 0x48, 0x12, 0xf5, 0x2e, 0xd4, 0xe9, 0x68, 0x4a, 0x66, 0x58, 0x5f, 0x15, 0x2a, 0x6a, 0xc4,
+	0x71, 0x9b, 0xb8, 0x84, 0x11, 0xbd, 0x29, 0x71, 0x39, 0x32, 0xbf, 0xad, 0x40, 0xe7, 0x3c, 0xe4,
+	0x81, 0xb1, 0xeb, 0x0b, 0xa9, 0x8a, 0xa0, 0xe6, 0xe1, 0x59, 0xb2, 0x17, 0xfc, 0x3b, 0xd9, 0x9f,
+	0x4a, 0xba, 0x3f, 0xc8, 0x80, 0xd5, 0x90, 0x7c, 0x1d, 0x39, 0x21, 0xb1, 0x15, 0x49, 0xc9, 0x18,
+	0x3d, 0x87, 0x86, 0x4d, 0xc6, 0x38, 0x72, 0x99, 0x60, 0xa7, 0x75, 0xd8, 0x3d, 0x90, 0x49, 0x72,
+	0x10, 0x27, 0xc9, 0xc1, 0x5b, 0xec, 0x46, 0xc4, 0x8a, 0xd5, 0x04, 0xe7, 0xc4, 0xa3, 0x0e, 0x73,
+	0xde, 0x11, 0xc5, 0x57, 0x0a, 0xa0, 0x47, 0xd0, 0xb2, 0x09, 0x1d, 0x85, 0x4e, 0x20, 0xb6, 0xac,
+	0x2e, 0xc2, 0xc8, 0x42, 0x3c, 0x42, 0xe2, 0x45, 0x33, 0xbd, 0xf1, 0xa8, 0xca, 0x23, 0xe4, 0xdf,
+	0x48, 0x87, 0x46, 0x80, 0x19, 0x23, 0xa1, 0x27, 0xd8, 0x68, 0x5a, 0xf1, 0x10, 0x7d, 0x01, 0x10,
+	0xc8, 0x55, 0x3b, 0x84, 0xea, 0xcd, 0x47, 0x55, 0x11, 0xa2, 0xca, 0xee, 0x3c, 0x1f, 0x56, 0x46,
+	0x13, 0x7d, 0x0f, 0x56, 0x1c, 0x46, 0x66, 0x54, 0x07, 0xb5, 0xaa, 0xf2, 0x29, 0x52, 0xc9, 0x44,
+	0xb0, 0x71, 0x4c, 0x98, 0xc2, 0x54, 0xee, 0xfe, 0x1e, 0x36, 0x33, 0x98, 0x4a, 0xda, 0x03, 0x50,
+	0xff, 0x92, 0xae, 0xdd, 0x18, 0x8a, 0xd2, 0x12, 0xe1, 0xe3, 0x10, 0xcf, 0x08, 0x23, 0x21, 0xd5,
+	0x2b, 0xb7, 0x84, 0x9f, 0x68, 0x9a, 0xeb, 0xb0, 0x76, 0x42, 0xb0, 0xcb, 0xa6, 0x71, 0x34, 0x1b,
+	0xd0, 0x89, 0x01, 0x19, 0x8a, 0xf9, 0x4f, 0x0d, 0x3a, 0x47, 0xa1, 0x3f, 0x7b, 0x63, 0xbd, 0x56,
+	0x4a, 0x68, 0x03, 0xaa, 0x51, 0xe8, 0xaa, 0x7c, 0xe0, 0x9f, 0xe8, 0x28, 0x47, 0x9f, 0xf4, 0xff,
+	0x24, 0xf6, 0x9f, 0x9f, 0x1d, 0x87, 0xe3, 0x10, 0xda, 0xf7, 0x58, 0x78, 0x9d, 0xa5, 0xd3, 0xf8,
+	0x19, 0xac, 0x17, 0xc4, 0xdc, 0xd9, 0x15, 0xb9, 0x8e, 0x9d, 0x5d, 0x91, 0x6b, 0xb4, 0x05, 0x2b,
+	0xef, 0x78, 0xae, 0xa8, 0xe4, 0x93, 0x83, 0x17, 0x95, 0x9f, 0x68, 0xe6, 0x4b, 0x58, 0x4f, 0x9c,
+	0x29, 0x26, 0x9f, 0x65, 0x98, 0xe4, 0x3b, 0xb4, 0x33, 0x97, 0x77, 0x17, 0xe2, 0x70, 0x8a, 0xa9,
+	0x34, 0x7f, 0x01, 0xed, 0x81, 0x9f, 0x59, 0xec, 0x07, 0x1b, 0xf8, 0x87, 0x06, 0x6b, 0x03, 0x3f,
+	0x1b, 0xc3, 0x3c, 0x5f, 0xfd, 0x12, 0xbe, 0x3e, 0x8b, 0xf9, 0xca, 0x4d, 0xfe, 0x5f, 0xd2, 0x75,
+	0x0c, 0x5b, 0xc7, 0x84, 0x9d, 0x27, 0xe9, 0xb0, 0xf4, 0x92, 0xcf, 0x61, 0xbb, 0x60, 0x48, 0xad,
+	0xfc, 0xc7, 0xb9, 0xbc, 0xbc, 0xc5, 0x5a, 0x36, 0x31, 0x4f, 0x60, 0xfb, 0x2d, 0x76, 0x1d, 0x1b,
+	0x33, 0x62, 0x09, 0x1f, 0x4b, 0xc7, 0xa6, 0x43, 0xb7, 0x68, 0x49, 0x65, 0xf6, 0x00, 0x1e, 0xc4,
+	0x92, 0x79, 0x0e, 0x96, 0x8e, 0x7c, 0x17, 0x8c, 0x32, 0xab, 0xca, 0xe7, 0x18, 0xaa, 0x03, 0x3c,
+	0x29, 0xd9, 0xa5, 0x7d, 0x00, 0xb1, 0x31, 0x43, 0x2f, 0x72, 0x5d, 0x79, 0x05, 0x9d, 0xdc, 0xb3,
+	0x9a, 0x02, 0xfb, 0x2a, 0x72, 0x5d, 0xf4, 0x29, 0xb4, 0xa5, 0x02, 0x65, 0x61, 0x7c, 0x0d, 0x35,
+	0x4f, 0xee, 0x59, 0x2d, 0x81, 0x5e, 0x08, 0xf0, 0x65, 0x43, 0xed, 0xb5, 0xf9, 0x6b, 0xa8, 0xf7,
+	0xfc, 0xd9, 0xcc, 0x61, 0xa8, 0x03, 0x15, 0xc7, 0x56, 0x9e, 0x2a, 0x8e, 0x2d, 0x16, 0x96, 0x4d,
+	0xbd, 0x5b, 0x16, 0x96, 0xa8, 0x9a, 0x7f, 0xd1, 0xc4, 0xe9, 0x25, 0xcd, 0x2e, 0xbb, 0x1d, 0x05,
+	0x5e, 0x2b, 0x77, 0xe6, 0x15, 0x7d, 0x02, 0xcd, 0x91, 0x70, 0x3d, 0x74, 0xe4, 0xf5, 0xd2, 0xb4,
+	0x56, 0x25, 0x70, 0x6a, 0x9b, 0x11, 0x6c, 0x66, 0x42, 0x53, 0xc9, 0xf7, 0x18, 0x5a, 0x6a, 0x86,
+	0xe0, 0x54, 0x53, 0x9c, 0x82, 0x04, 0x05, 0xa9, 0x9f, 0x43, 0x5b, 0xa9, 0xa4, 0xbf, 0x48, 0xeb,
+	0xb0, 0x13, 0xff, 0x89, 0xd2, 0x20, 0x27, 0x59, 0x6a, 0x89, 0x2b, 0xea, 0xe5, 0x2a, 0xd4, 0xe5,
+	0xd0, 0xfc, 0x56, 0x83, 0xcd, 0xd7, 0x0e, 0xfd, 0xbf, 0x71, 0xb2, 0x0f, 0x35, 0x86, 0x27, 0x54,
+	0xaf, 0x8a, 0x03, 0xa4, 0x95, 0x1c, 0x20, 0x78, 0x62, 0x09, 0x01, 0x27, 0x2d, 0xc0, 0x13, 0x32,
+	0xa4, 0xce, 0x37, 0x44, 0x5c, 0xbc, 0x2b, 0xd6, 0x2a, 0x07, 0x2e, 0x9c, 0x6f, 0x08, 0x7a, 0x28,
+	0xaa, 0x1e, 0x32, 0x64, 0xfe, 0x15, 0xf1, 0xc4, 0x15, 0xdb, 0xb4, 0x84, 0xfa, 0x80, 0x03, 0xe6,
+	0x18, 0x50, 0x76, 0x6d, 0x8a, 0xd4, 0xa7, 0xd0, 0x90, 0x8b, 0xa7, 0xea, 0x6a, 0x2a, 0x90, 0x65,
+	0xc5, 0x62, 0xf4, 0x04, 0xd6, 0x3d, 0xf2, 0x9e, 0x0d, 0x33, 0x3e, 0xe4, 0x09, 0xb4, 0xc6, 0xe1,
+	0xf3, 0xc4, 0xcf, 0xbf, 0x35, 0x68, 0x9e, 0xc5, 0x85, 0xd0, 0x5c, 0xba, 0x96, 0x15, 0x1a, 0x37,
+	0xa5, 0x42, 0x86, 0xfd, 0xda, 0x32, 0xec, 0xaf, 0xdc, 0x9d, 0xfd, 0xef, 0x42, 0xcd, 0xc6, 0x0c,
+	0xeb, 0xf5, 0x9b, 0xa7, 0x08, 0x25, 0xf3, 0x8f, 0x1a, 0xd4, 0xdf, 0x8a, 0x4a, 0xae, 0xb4, 0x9e,
+	0x5a, 0xf6, 0xaf, 0xe4, 0xc6, 0x02, 0xcc, 0xa6, 0x8a, 0x06, 0xf1, 0x8d, 0x1e, 0x43, 0x9b, 0x8e,
+	0x42, 0xcc, 0x46, 0xd3, 0xa1, 0x90, 0xd5, 0x84, 0xac, 0xa5, 0xb0, 0x73, 0xcc, 0xa6, 0xfc, 0x7c,
+	0xbd, 0x60, 0x38, 0x64, 0x09, 0xf1, 0x69, 0xf2, 0x36, 0x93, 0xaa, 0x54, 0xe5, 0xef, 0x66, 0xbc,
+	0xc3, 0xa9, 0x72, 0xaa, 0x63, 0xf6, 0xa1, 0x5b, 0xb4, 0xa4, 0x52, 0x25, 0xe6, 0x47, 0xbb, 0x0b,
+	0x3f, 0x7f, 0xd0, 0xe0, 0xfe, 0x79, 0x44, 0xa7, 0x5f, 0x12, 0x86, 0x39, 0xb0, 0x6c, 0x3c, 0xe8,
+	0x49, 0xfc, 0x77, 0x96, 0xff, 0xcc, 0x96, 0x92, 0xf2, 0x24, 0x72, 0xe8, 0x30, 0x0a, 0xf8, 0x41,
+	0x1d, 0x97, 0xab, 0x0e, 0x7d, 0x23, 0xc6, 0x66, 0x17, 0xb6, 0xf2, 0xc1, 0xa8, 0xe3, 0xdb, 0x85,
+	0xcd, 0x8b, 0x6b, 0x6f, 0x24, 0x37, 0xf2, 0x63, 0x42, 0x94, 0x45, 0x7d, 0x31, 0x44, 0x65, 0x57,
+	0x49, 0xcd, 0x2d, 0x40, 0x59, 0x6f, 0x2a, 0x86, 0x23, 0xb8, 0xdf, 0xf7, 0xec, 0x8f, 0xdf, 0xb8,
+	0x2e, 0x6c, 0xe5, 0xed, 0x28, 0xfb, 0x43, 0xd8, 0x3a, 0xc2, 0x8e, 0xfb, 0xd1, 0x0e, 0x78, 0x8b,
+	0x11, 0x12, 0x4c, 0xfd, 0xf8, 0xbf, 0x57, 0x23, 0x73, 0x07, 0xb6, 0x0b, 0x0e, 0x94, 0xe7, 0xbf,
+	0x6a, 0xd0, 0x92, 0x8b, 0xed, 0x4d, 0x23, 0xef, 0x6a, 0x29, 0x8f, 0x19, 0x62, 0x9b, 0x31, 0x91,
+	0x1c, 0xf7, 0xc7, 0x63, 0x4a, 0x98, 0xd8, 0xe8, 0xaa, 0xa5, 0x46, 0xfc, 0xe7, 0x11, 0x19, 0xca,
+	0x7f, 0x90, 0xb6, 0x4c, 0x44, 0xde, 0xc5, 0x8c, 0xa6, 0x64, 0x74, 0x45, 0xa3, 0x99, 0x38, 0x0c,
+	0xd6, 0xac, 0x64, 0x6c, 0x1e, 0xc0, 0xd6, 0x9b, 0xc0, 0xf5, 0xb1, 0x9d, 0xdf, 0x92, 0x8c, 0x7d,
+	0x2d, 0x6b, 0xdf, 0x7c, 0x0f, 0xdb, 0xaf, 0xfc, 0xdf, 0x79, 0xd9, 0x19, 0xcb, 0x73, 0xf9, 0x21,
+	0x2b, 0x33, 0xb1, 0xe8, 0x95, 0xa5, 0xd3, 0x33, 0x01, 0xfd, 0xb7, 0x5d, 0x9b, 0x3f, 0x80, 0x9d,
+	0x39, 0x17, 0xb7, 0xf0, 0xf1, 0x37, 0x0d, 0xb6, 0x2d, 0x12, 0xf8, 0x21, 0x3b, 0x0f, 0xfd, 0x49,
+	0x48, 0x68, 0x52, 0x6e, 0xed, 0x40, 0x63, 0x84, 0x5d, 0x77, 0x98, 0x9c, 0xfd, 0x75, 0x3e, 0x3c,
+	0xb5, 0x79, 0xf5, 0x1a, 0x4c, 0x31, 0x4d, 0xaa, 0x57, 0x31, 0xe0, 0x8d, 0xdc, 0x8c, 0x50, 0x8a,
+	0x27, 0x44, 0x1d, 0x7c, 0xf1, 0x90, 0x5f, 0x6a, 0x97, 0xd7, 0x8c, 0xd0, 0xa1, 0xed, 0x7b, 0xf2,
+	0x0a, 0xa8, 0x5a, 0x4d, 0x81, 0xbc, 0xf2, 0x3d, 0x82, 0xf6, 0xa1, 0x25, 0xc5, 0xcc, 0x67, 0xd8,
+	0x15, 0x1b, 0x5c, 0xb5, 0xe4, 0x8c, 0x01, 0x47, 0x78, 0xb9, 0x58, 0x8c, 0x50, 0x65, 0xe7, 0x9f,
+	0x2a, 0xb0, 0x76, 0x1e, 0xfa, 0xb3, 0x80, 0xdd, 0x1a, 0xf4, 0x01, 0xd4, 0xae, 0x1c, 0xcf, 0x16,
+	0x31, 0x77, 0x0e, 0x8d, 0x4c, 0x23, 0x96, 0xce, 0x3e, 0xf8, 0x95, 0xe3, 0xd9, 0x96, 0xd0, 0xbb,
+	0x61, 0x39, 0x8f, 0xa1, 0xad, 0x1a, 0xe2, 0x21, 0x23, 0xef, 0x59, 0x7c, 0x94, 0x2b, 0x6c, 0x40,
+	0xde, 0x33, 0xfe, 0x72, 0x10, 0xab, 0x8c, 0x7c, 0x6f, 0xec, 0x84, 0x33, 0xd5, 0x2e, 0x77, 0x14,
+	0xdc, 0x93, 0x28, 0xf7, 0x32, 0x9a, 0xfa, 0xce, 0x88, 0x50, 0xbd, 0x2e, 0x9a, 0xe2, 0x78, 0x68,
+	0xfe, 0x08, 0x6a, 0x3c, 0x1a, 0xb4, 0x0a, 0xb5, 0x41, 0xff, 0x37, 0x83, 0x8d, 0x7b, 0x08, 0xa0,
+	0x7e, 0xd1, 0xef, 0x59, 0xfd, 0xc1, 0x86, 0x86, 0x5a, 0xd0, 0xe8, 0x9d, 0x7d, 0x75, 0x74, 0x6a,
+	0x7d, 0xb9, 0x51, 0xe1, 0x82, 0xde, 0xc9, 0xd9, 0x69, 0xaf, 0xbf, 0x51, 0x35, 0x5f, 0x42, 0x27,
+	0x5e, 0x52, 0xe6, 0xd9, 0x86, 0x87, 0x19, 0x3f, 0xdb, 0xf0, 0xf8, 0x76, 0xf9, 0x6d, 0x2d, 0x22,
+	0x20, 0xb6, 0x7a, 0x5b, 0x49, 0x01, 0x93, 0x42, 0xab, 0x1f, 0x86, 0x7e, 0xf8, 0x8a, 0x30, 0xec,
+	0xb8, 0xdc, 0x80, 0x60, 0x4e, 0x19, 0x28, 0xb2, 0x53, 0xc9, 0xb3, 0xf3, 0x05, 0xc0, 0x3b, 0xc7,
+	0x77, 0xd5, 0xa3, 0x4a, 0x35, 0xdf, 0xf6, 0x1e, 0x39, 0xc4, 0xb5, 0xdf, 0xc6, 0x62, 0x2b, 0xa3,
+	0x69, 0xfe, 0x1c, 0x3a, 0x79, 0x69, 0x72, 0x8d, 0x6a, 0x99, 0x6b, 0x74, 0xa1, 0xdf, 0xc3, 0xbf,
+	0xb7, 0xa0, 0x2e, 0x9b, 0x09, 0xf4, 0x02, 0x1a, 0xea, 0xed, 0x0a, 0x25, 0x9e, 0xf3, 0xcf, 0x5b,
+	0xc6, 0xce, 0x1c, 0xae, 0xd8, 0xb2, 0x60, 0xbd, 0xf0, 0x6a, 0x85, 0xf6, 0x32, 0xba, 0x25, 0x2f,
+	0x5d, 0xc6, 0xfe, 0x42, 0xb9, 0xb2, 0xf9, 0x4b, 0x68, 0x26, 0xcf, 0x09, 0x48, 0xcf, 0x68, 0xe7,
+	0x5e, 0x1d, 0x8c, 0x07, 0x25, 0x12, 0x65, 0xe1, 0x05, 0x34, 0x54, 0x13, 0x9d, 0xae, 0x28, 0xdf,
+	0xc2, 0x1b, 0x3b, 0x73, 0xb8, 0x9a, 0xfb, 0x43, 0x58, 0x11, 0xdd, 0x2b, 0xda, 0x2a, 0x34, 0xb3,
+	0x72, 0xde, 0x76, 0x69, 0x8b, 0x8b, 0x5e, 0xc3, 0x5a, 0xae, 0x7d, 0x44, 0xbb, 0x99, 0xe8, 0xe6,
+	0x5a, 0x33, 0xe3, 0xe1, 0x02, 0xa9, 0xb2, 0x76, 0x06, 0x9d, 0x7c, 0xc3, 0x87, 0x92, 0x09, 0xa5,
+	0x2d, 0xa5, 0xb1, 0xb7, 0x48, 0xac, 0x0c, 0xfe, 0x16, 0xd0, 0x7c, 0x47, 0x87, 0x1e, 0x17, 0x67,
+	0xcd, 0x07, 0x6a, 0xde, 0xa4, 0xa2, 0x8c, 0xbf, 0x82, 0x56, 0x5a, 0x65, 0x53, 0x94, 0xec, 0xcb,
+	0x5c, 0x5b, 0x61, 0x18, 0x65, 0xa2, 0xdc, 0xae, 0x4b, 0x30, 0xb7, 0xeb, 0x79, 0x13, 0x0f, 0x4a,
+	0x24, 0xca, 0xc2, 0x4f, 0x61, 0xed, 0x42, 0xbc, 0x68, 0xde, 0x21, 0x92, 0x42, 0x49, 0xf5, 0x5c,
+	0xe3, 0x9c, 0xe7, 0x8b, 0xc0, 0x94, 0xf3, 0xd2, 0x32, 0xd3, 0xd8, 0x5b, 0x24, 0x56, 0xe1, 0x9c,
+	0x42, 0x3b, 0x5b, 0x80, 0xa1, 0x4f, 0x92, 0x33, 0x74, 0xbe, 0x46, 0x34, 0x76, 0xcb, 0x85, 0xca,
+	0x54, 0x0f, 0x20, 0xad, 0xa2, 0xd2, 0x65, 0xcd, 0xd5, 0x71, 0x86, 0x51, 0x26, 0x4a, 0xe3, 0xc9,
+	0x16, 0x4b, 0x69, 0x3c, 0x25, 0xa5, 0x98, 0xb1, 0x5b, 0x2e, 0x4c, 0xb3, 0x3d, 0x57, 0xfe, 0xa4,
+	0xd9, 0x5e, 0x56, 0x76, 0x19, 0x0f, 0x17, 0x48, 0x93, 0xd5, 0xb5, 0xb3, 0x25, 0x09, 0xba, 0x9f,
+	0xaf, 0x25, 0x45, 0x21, 0x95, 0x06, 0x54, 0x56, 0xbd, 0x3c, 0xd5, 0xd0, 0x11, 0x74, 0xf2, 0x75,
+	0x4a, 0xba, 0x7d, 0xa5, 0xf5, 0x8b, 0x51, 0xe6, 0xe5, 0xb9, 0xa6, 0x0e, 0xb4, 0x6c, 0x49, 0x90,
+	0x3b, 0xd0, 0x4a, 0xca, 0x11, 0x63, 0x7f, 0xa1, 0x3c, 0x79, 0x42, 0xaa, 0xcb, 0x17, 0x49, 0x94,
+	0x9c, 0x1e, 0xb9, 0x27, 0x4b, 0xa3, 0x5b, 0x84, 0xe5, 0xc4, 0xc3, 0x3f, 0x6b, 0x50, 0x3b, 0xf1,
+	0x29, 0xe3, 0xc9, 0x99, 0xbf, 0xd2, 0xd3, 0xd5, 0x95, 0x16, 0x23, 0xc6, 0xde, 0x22, 0x71, 0x1a,
+	0x92, 0xbc, 0xf7, 0xd2, 0x90, 0x72, 0x57, 0xbb, 0xd1, 0x2d, 0xc2, 0x72, 0xe2, 0x65, 0x5d, 0xf4,
+	0x3e, 0x9f, 0xff, 0x67, 0x00, 0x3b, 0xd5, 0x95, 0x0a, 0x37, 0x19, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ context.Context
-var _ grpc.ClientConn
+var _ grpc.ClientConnInterface
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+const _ = grpc.SupportPackageIsVersion6
 
 // RemoteClient is the client API for Remote service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type RemoteClient interface {
 	GetType(ctx context.Context, in *GetTypeRequest, opts ...grpc.CallOption) (*GetTypeResponse, error)
+	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error)
+	GetSchema(ctx context.Context, in *GetSchemaRequest, opts ...grpc.CallOption) (*GetSchemaResponse, error)
 	FromURL(ctx context.Context, in *FromURLRequest, opts ...grpc.CallOption) (*FromURLResponse, error)
 	ToURL(ctx context.Context, in *ToURLRequest, opts ...grpc.CallOption) (*ToURLResponse, error)
 	GetParameters(ctx context.Context, in *GetParametersRequest, opts ...grpc.CallOption) (*GetParametersResponse, error)
@@ -942,13 +2609,23 @@ type RemoteClient interface {
 	ValidateParameters(ctx context.Context, in *ValidateParametersRequest, opts ...grpc.CallOption) (*ValidateParametersResponse, error)
 	ListCommits(ctx context.Context, in *ListCommitRequest, opts ...grpc.CallOption) (*ListCommitResponse, error)
 	GetCommit(ctx context.Context, in *GetCommitRequest, opts ...grpc.CallOption) (*GetCommitResponse, error)
+	StreamCommits(ctx context.Context, in *ListCommitRequest, opts ...grpc.CallOption) (Remote_StreamCommitsClient, error)
+	StartOperation(ctx context.Context, in *StartOperationRequest, opts ...grpc.CallOption) (*StartOperationResponse, error)
+	PushMetadata(ctx context.Context, in *PushMetadataRequest, opts ...grpc.CallOption) (*PushMetadataResponse, error)
+	SyncVolume(ctx context.Context, in *SyncVolumeRequest, opts ...grpc.CallOption) (*SyncVolumeResponse, error)
+	EndOperation(ctx context.Context, in *EndOperationRequest, opts ...grpc.CallOption) (*EndOperationResponse, error)
+	FailOperation(ctx context.Context, in *FailOperationRequest, opts ...grpc.CallOption) (*FailOperationResponse, error)
+	UploadVolume(ctx context.Context, opts ...grpc.CallOption) (Remote_UploadVolumeClient, error)
+	DownloadVolume(ctx context.Context, in *DownloadVolumeRequest, opts ...grpc.CallOption) (Remote_DownloadVolumeClient, error)
+	GetVolumeOffset(ctx context.Context, in *GetVolumeOffsetRequest, opts ...grpc.CallOption) (*GetVolumeOffsetResponse, error)
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
 }
 
 type remoteClient struct {
-	cc *grpc.ClientConn
+	cc grpc.ClientConnInterface
 }
 
-func NewRemoteClient(cc *grpc.ClientConn) RemoteClient {
+func NewRemoteClient(cc grpc.ClientConnInterface) RemoteClient {
 	return &remoteClient{cc}
 }
 
@@ -961,6 +2638,24 @@ func (c *remoteClient) GetType(ctx context.Context, in *GetTypeRequest, opts ...
 	return out, nil
 }
 
+func (c *remoteClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error) {
+	out := new(GetCapabilitiesResponse)
+	err := c.cc.Invoke(ctx, "/remote.Remote/GetCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) GetSchema(ctx context.Context, in *GetSchemaRequest, opts ...grpc.CallOption) (*GetSchemaResponse, error) {
+	out := new(GetSchemaResponse)
+	err := c.cc.Invoke(ctx, "/remote.Remote/GetSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *remoteClient) FromURL(ctx context.Context, in *FromURLRequest, opts ...grpc.CallOption) (*FromURLResponse, error) {
 	out := new(FromURLResponse)
 	err := c.cc.Invoke(ctx, "/remote.Remote/FromURL", in, out, opts...)
@@ -1003,21 +2698,182 @@ func (c *remoteClient) ValidateParameters(ctx context.Context, in *ValidateParam
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *remoteClient) ListCommits(ctx context.Context, in *ListCommitRequest, opts ...grpc.CallOption) (*ListCommitResponse, error) {
+	out := new(ListCommitResponse)
+	err := c.cc.Invoke(ctx, "/remote.Remote/ListCommits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) GetCommit(ctx context.Context, in *GetCommitRequest, opts ...grpc.CallOption) (*GetCommitResponse, error) {
+	out := new(GetCommitResponse)
+	err := c.cc.Invoke(ctx, "/remote.Remote/GetCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) StreamCommits(ctx context.Context, in *ListCommitRequest, opts ...grpc.CallOption) (Remote_StreamCommitsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Remote_serviceDesc.Streams[0], "/remote.Remote/StreamCommits", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &remoteStreamCommitsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Remote_StreamCommitsClient interface {
+	Recv() (*Commit, error)
+	grpc.ClientStream
+}
+
+type remoteStreamCommitsClient struct {
+	grpc.ClientStream
+}
+
+func (x *remoteStreamCommitsClient) Recv() (*Commit, error) {
+	m := new(Commit)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *remoteClient) StartOperation(ctx context.Context, in *StartOperationRequest, opts ...grpc.CallOption) (*StartOperationResponse, error) {
+	out := new(StartOperationResponse)
+	err := c.cc.Invoke(ctx, "/remote.Remote/StartOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) PushMetadata(ctx context.Context, in *PushMetadataRequest, opts ...grpc.CallOption) (*PushMetadataResponse, error) {
+	out := new(PushMetadataResponse)
+	err := c.cc.Invoke(ctx, "/remote.Remote/PushMetadata", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) SyncVolume(ctx context.Context, in *SyncVolumeRequest, opts ...grpc.CallOption) (*SyncVolumeResponse, error) {
+	out := new(SyncVolumeResponse)
+	err := c.cc.Invoke(ctx, "/remote.Remote/SyncVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) EndOperation(ctx context.Context, in *EndOperationRequest, opts ...grpc.CallOption) (*EndOperationResponse, error) {
+	out := new(EndOperationResponse)
+	err := c.cc.Invoke(ctx, "/remote.Remote/EndOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) FailOperation(ctx context.Context, in *FailOperationRequest, opts ...grpc.CallOption) (*FailOperationResponse, error) {
+	out := new(FailOperationResponse)
+	err := c.cc.Invoke(ctx, "/remote.Remote/FailOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) UploadVolume(ctx context.Context, opts ...grpc.CallOption) (Remote_UploadVolumeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Remote_serviceDesc.Streams[1], "/remote.Remote/UploadVolume", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &remoteUploadVolumeClient{stream}
+	return x, nil
+}
+
+type Remote_UploadVolumeClient interface {
+	Send(*VolumeChunk) error
+	CloseAndRecv() (*UploadVolumeResponse, error)
+	grpc.ClientStream
+}
+
+type remoteUploadVolumeClient struct {
+	grpc.ClientStream
+}
+
+func (x *remoteUploadVolumeClient) Send(m *VolumeChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *remoteUploadVolumeClient) CloseAndRecv() (*UploadVolumeResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadVolumeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *remoteClient) DownloadVolume(ctx context.Context, in *DownloadVolumeRequest, opts ...grpc.CallOption) (Remote_DownloadVolumeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Remote_serviceDesc.Streams[2], "/remote.Remote/DownloadVolume", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &remoteDownloadVolumeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Remote_DownloadVolumeClient interface {
+	Recv() (*VolumeChunk, error)
+	grpc.ClientStream
+}
+
+type remoteDownloadVolumeClient struct {
+	grpc.ClientStream
+}
+
+func (x *remoteDownloadVolumeClient) Recv() (*VolumeChunk, error) {
+	m := new(VolumeChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func (c *remoteClient) ListCommits(ctx context.Context, in *ListCommitRequest, opts ...grpc.CallOption) (*ListCommitResponse, error) {
-	out := new(ListCommitResponse)
-	err := c.cc.Invoke(ctx, "/remote.Remote/ListCommits", in, out, opts...)
+func (c *remoteClient) GetVolumeOffset(ctx context.Context, in *GetVolumeOffsetRequest, opts ...grpc.CallOption) (*GetVolumeOffsetResponse, error) {
+	out := new(GetVolumeOffsetResponse)
+	err := c.cc.Invoke(ctx, "/remote.Remote/GetVolumeOffset", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *remoteClient) GetCommit(ctx context.Context, in *GetCommitRequest, opts ...grpc.CallOption) (*GetCommitResponse, error) {
-	out := new(GetCommitResponse)
-	err := c.cc.Invoke(ctx, "/remote.Remote/GetCommit", in, out, opts...)
+func (c *remoteClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, "/remote.Remote/Health", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1027,6 +2883,8 @@ func (c *remoteClient) GetCommit(ctx context.Context, in *GetCommitRequest, opts
 // RemoteServer is the server API for Remote service.
 type RemoteServer interface {
 	GetType(context.Context, *GetTypeRequest) (*GetTypeResponse, error)
+	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error)
+	GetSchema(context.Context, *GetSchemaRequest) (*GetSchemaResponse, error)
 	FromURL(context.Context, *FromURLRequest) (*FromURLResponse, error)
 	ToURL(context.Context, *ToURLRequest) (*ToURLResponse, error)
 	GetParameters(context.Context, *GetParametersRequest) (*GetParametersResponse, error)
@@ -1034,6 +2892,16 @@ type RemoteServer interface {
 	ValidateParameters(context.Context, *ValidateParametersRequest) (*ValidateParametersResponse, error)
 	ListCommits(context.Context, *ListCommitRequest) (*ListCommitResponse, error)
 	GetCommit(context.Context, *GetCommitRequest) (*GetCommitResponse, error)
+	StreamCommits(*ListCommitRequest, Remote_StreamCommitsServer) error
+	StartOperation(context.Context, *StartOperationRequest) (*StartOperationResponse, error)
+	PushMetadata(context.Context, *PushMetadataRequest) (*PushMetadataResponse, error)
+	SyncVolume(context.Context, *SyncVolumeRequest) (*SyncVolumeResponse, error)
+	EndOperation(context.Context, *EndOperationRequest) (*EndOperationResponse, error)
+	FailOperation(context.Context, *FailOperationRequest) (*FailOperationResponse, error)
+	UploadVolume(Remote_UploadVolumeServer) error
+	DownloadVolume(*DownloadVolumeRequest, Remote_DownloadVolumeServer) error
+	GetVolumeOffset(context.Context, *GetVolumeOffsetRequest) (*GetVolumeOffsetResponse, error)
+	Health(context.Context, *HealthRequest) (*HealthResponse, error)
 }
 
 // UnimplementedRemoteServer can be embedded to have forward compatible implementations.
@@ -1043,6 +2911,12 @@ type UnimplementedRemoteServer struct {
 func (*UnimplementedRemoteServer) GetType(ctx context.Context, req *GetTypeRequest) (*GetTypeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetType not implemented")
 }
+func (*UnimplementedRemoteServer) GetCapabilities(ctx context.Context, req *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
+func (*UnimplementedRemoteServer) GetSchema(ctx context.Context, req *GetSchemaRequest) (*GetSchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchema not implemented")
+}
 func (*UnimplementedRemoteServer) FromURL(ctx context.Context, req *FromURLRequest) (*FromURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FromURL not implemented")
 }
@@ -1064,6 +2938,36 @@ func (*UnimplementedRemoteServer) ListCommits(ctx context.Context, req *ListComm
 func (*UnimplementedRemoteServer) GetCommit(ctx context.Context, req *GetCommitRequest) (*GetCommitResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCommit not implemented")
 }
+func (*UnimplementedRemoteServer) StreamCommits(req *ListCommitRequest, srv Remote_StreamCommitsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamCommits not implemented")
+}
+func (*UnimplementedRemoteServer) StartOperation(ctx context.Context, req *StartOperationRequest) (*StartOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartOperation not implemented")
+}
+func (*UnimplementedRemoteServer) PushMetadata(ctx context.Context, req *PushMetadataRequest) (*PushMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushMetadata not implemented")
+}
+func (*UnimplementedRemoteServer) SyncVolume(ctx context.Context, req *SyncVolumeRequest) (*SyncVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncVolume not implemented")
+}
+func (*UnimplementedRemoteServer) EndOperation(ctx context.Context, req *EndOperationRequest) (*EndOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndOperation not implemented")
+}
+func (*UnimplementedRemoteServer) FailOperation(ctx context.Context, req *FailOperationRequest) (*FailOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FailOperation not implemented")
+}
+func (*UnimplementedRemoteServer) UploadVolume(srv Remote_UploadVolumeServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadVolume not implemented")
+}
+func (*UnimplementedRemoteServer) DownloadVolume(req *DownloadVolumeRequest, srv Remote_DownloadVolumeServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadVolume not implemented")
+}
+func (*UnimplementedRemoteServer) GetVolumeOffset(ctx context.Context, req *GetVolumeOffsetRequest) (*GetVolumeOffsetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVolumeOffset not implemented")
+}
+func (*UnimplementedRemoteServer) Health(ctx context.Context, req *HealthRequest) (*HealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+}
 
 func RegisterRemoteServer(s *grpc.Server, srv RemoteServer) {
 	s.RegisterService(&_Remote_serviceDesc, srv)
@@ -1087,6 +2991,42 @@ func _Remote_GetType_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Remote_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Remote/GetCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).GetCapabilities(ctx, req.(*GetCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Remote_GetSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).GetSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Remote/GetSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).GetSchema(ctx, req.(*GetSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Remote_FromURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FromURLRequest)
 	if err := dec(in); err != nil {
@@ -1213,6 +3153,200 @@ func _Remote_GetCommit_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Remote_StreamCommits_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListCommitRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RemoteServer).StreamCommits(m, &remoteStreamCommitsServer{stream})
+}
+
+type Remote_StreamCommitsServer interface {
+	Send(*Commit) error
+	grpc.ServerStream
+}
+
+type remoteStreamCommitsServer struct {
+	grpc.ServerStream
+}
+
+func (x *remoteStreamCommitsServer) Send(m *Commit) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Remote_StartOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).StartOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Remote/StartOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).StartOperation(ctx, req.(*StartOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Remote_PushMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).PushMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Remote/PushMetadata",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).PushMetadata(ctx, req.(*PushMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Remote_SyncVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).SyncVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Remote/SyncVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).SyncVolume(ctx, req.(*SyncVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Remote_EndOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).EndOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Remote/EndOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).EndOperation(ctx, req.(*EndOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Remote_FailOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FailOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).FailOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Remote/FailOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).FailOperation(ctx, req.(*FailOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Remote_UploadVolume_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RemoteServer).UploadVolume(&remoteUploadVolumeServer{stream})
+}
+
+type Remote_UploadVolumeServer interface {
+	SendAndClose(*UploadVolumeResponse) error
+	Recv() (*VolumeChunk, error)
+	grpc.ServerStream
+}
+
+type remoteUploadVolumeServer struct {
+	grpc.ServerStream
+}
+
+func (x *remoteUploadVolumeServer) SendAndClose(m *UploadVolumeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *remoteUploadVolumeServer) Recv() (*VolumeChunk, error) {
+	m := new(VolumeChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Remote_DownloadVolume_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadVolumeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RemoteServer).DownloadVolume(m, &remoteDownloadVolumeServer{stream})
+}
+
+type Remote_DownloadVolumeServer interface {
+	Send(*VolumeChunk) error
+	grpc.ServerStream
+}
+
+type remoteDownloadVolumeServer struct {
+	grpc.ServerStream
+}
+
+func (x *remoteDownloadVolumeServer) Send(m *VolumeChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Remote_GetVolumeOffset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVolumeOffsetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).GetVolumeOffset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Remote/GetVolumeOffset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).GetVolumeOffset(ctx, req.(*GetVolumeOffsetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Remote_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Remote/Health",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).Health(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Remote_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "remote.Remote",
 	HandlerType: (*RemoteServer)(nil),
@@ -1221,6 +3355,14 @@ var _Remote_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetType",
 			Handler:    _Remote_GetType_Handler,
 		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _Remote_GetCapabilities_Handler,
+		},
+		{
+			MethodName: "GetSchema",
+			Handler:    _Remote_GetSchema_Handler,
+		},
 		{
 			MethodName: "FromURL",
 			Handler:    _Remote_FromURL_Handler,
@@ -1249,6 +3391,158 @@ var _Remote_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetCommit",
 			Handler:    _Remote_GetCommit_Handler,
 		},
+		{
+			MethodName: "StartOperation",
+			Handler:    _Remote_StartOperation_Handler,
+		},
+		{
+			MethodName: "PushMetadata",
+			Handler:    _Remote_PushMetadata_Handler,
+		},
+		{
+			MethodName: "SyncVolume",
+			Handler:    _Remote_SyncVolume_Handler,
+		},
+		{
+			MethodName: "EndOperation",
+			Handler:    _Remote_EndOperation_Handler,
+		},
+		{
+			MethodName: "FailOperation",
+			Handler:    _Remote_FailOperation_Handler,
+		},
+		{
+			MethodName: "GetVolumeOffset",
+			Handler:    _Remote_GetVolumeOffset_Handler,
+		},
+		{
+			MethodName: "Health",
+			Handler:    _Remote_Health_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamCommits",
+			Handler:       _Remote_StreamCommits_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadVolume",
+			Handler:       _Remote_UploadVolume_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadVolume",
+			Handler:       _Remote_DownloadVolume_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "remote.proto",
+}
+
+// HostClient is the client API for Host service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HostClient interface {
+	ReportProgress(ctx context.Context, in *ReportProgressRequest, opts ...grpc.CallOption) (*ReportProgressResponse, error)
+	Prompt(ctx context.Context, in *PromptRequest, opts ...grpc.CallOption) (*PromptResponse, error)
+}
+
+type hostClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHostClient(cc grpc.ClientConnInterface) HostClient {
+	return &hostClient{cc}
+}
+
+func (c *hostClient) ReportProgress(ctx context.Context, in *ReportProgressRequest, opts ...grpc.CallOption) (*ReportProgressResponse, error) {
+	out := new(ReportProgressResponse)
+	err := c.cc.Invoke(ctx, "/remote.Host/ReportProgress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostClient) Prompt(ctx context.Context, in *PromptRequest, opts ...grpc.CallOption) (*PromptResponse, error) {
+	out := new(PromptResponse)
+	err := c.cc.Invoke(ctx, "/remote.Host/Prompt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HostServer is the server API for Host service.
+type HostServer interface {
+	ReportProgress(context.Context, *ReportProgressRequest) (*ReportProgressResponse, error)
+	Prompt(context.Context, *PromptRequest) (*PromptResponse, error)
+}
+
+// UnimplementedHostServer can be embedded to have forward compatible implementations.
+type UnimplementedHostServer struct {
+}
+
+func (*UnimplementedHostServer) ReportProgress(ctx context.Context, req *ReportProgressRequest) (*ReportProgressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportProgress not implemented")
+}
+func (*UnimplementedHostServer) Prompt(ctx context.Context, req *PromptRequest) (*PromptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Prompt not implemented")
+}
+
+func RegisterHostServer(s *grpc.Server, srv HostServer) {
+	s.RegisterService(&_Host_serviceDesc, srv)
+}
+
+func _Host_ReportProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServer).ReportProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Host/ReportProgress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServer).ReportProgress(ctx, req.(*ReportProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Host_Prompt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServer).Prompt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Host/Prompt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServer).Prompt(ctx, req.(*PromptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Host_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "remote.Host",
+	HandlerType: (*HostServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReportProgress",
+			Handler:    _Host_ReportProgress_Handler,
+		},
+		{
+			MethodName: "Prompt",
+			Handler:    _Host_Prompt_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "remote.proto",
@@ -54,7 +54,7 @@ func elabValue(value *protobuf_struct.Value) (interface{}, error) {
 func Struct2Map(str *protobuf_struct.Struct) (map[string]interface{}, error) {
 	var err error
 	result := make(map[string]interface{})
-	for k, v := range str.Fields {
+	for k, v := range str.GetFields() {
 		result[k], err = elabValue(v)
 		if err != nil {
 			return nil, err
@@ -126,3 +126,11 @@ func Map2Struct(input map[string]interface{}) (*protobuf_struct.Struct, error) {
 func Struct2ProtobufStruct(input interface{}) (*protobuf_struct.Struct, error) {
 	return Map2Struct(structs.Map(input))
 }
+
+func Value2Interface(value *protobuf_struct.Value) (interface{}, error) {
+	return elabValue(value)
+}
+
+func Interface2Value(entry interface{}) (*protobuf_struct.Value, error) {
+	return elabEntry(entry)
+}
@@ -0,0 +1,382 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package memory
+
+import (
+	"context"
+	"github.com/titan-data/remote-sdk-go/remote"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+/*
+ * Reference remote that keeps commits in memory, for integration tests that need a remote with real behavior. Each
+ * repository is identified by a URL of the form "memory://<repository>", and holds its own set of commits. Commits
+ * can be added, updated, and deleted directly, or through push operations as the server would: pushing metadata adds
+ * or updates a commit, and a push operation whose "delete" parameter is true deletes the commit being pushed. Changes
+ * only take effect once the operation ends. Listing commits honors tag filters and returns them in reverse timestamp
+ * order.
+ *
+ * The remote can be used in-process, or served as a plugin by the "memory" command, in which case commits can only be
+ * changed through operations. Plugins only keep their commits for as long as the plugin process runs.
+ */
+
+const (
+	Type   = "memory"
+	scheme = Type + "://"
+)
+
+var schema = remote.Schema{
+	Remote: []remote.Property{{
+		Name:        "repository",
+		Type:        remote.TypeString,
+		Required:    true,
+		Pattern:     `^[^/?#]+$`,
+		Description: "Name of the repository within the remote",
+	}},
+	Parameters: []remote.Property{{
+		Name:        "delete",
+		Type:        remote.TypeBoolean,
+		Description: "Delete the commit when the push operation ends",
+	}},
+}
+
+type MemoryRemote struct {
+	mu           sync.Mutex
+	repositories map[string]map[string]remote.Commit
+	pending      map[string][]push
+}
+
+/*
+ * A change to a commit staged by an operation, applied only once the operation ends successfully. Deletes only use
+ * the ID of the commit.
+ */
+type push struct {
+	commit   remote.Commit
+	isUpdate bool
+	delete   bool
+}
+
+/*
+ * Create a new, empty in-memory remote.
+ */
+func New() *MemoryRemote {
+	return &MemoryRemote{
+		repositories: map[string]map[string]remote.Commit{},
+		pending:      map[string][]push{},
+	}
+}
+
+func (m *MemoryRemote) Type() (string, error) {
+	return Type, nil
+}
+
+func (m *MemoryRemote) Manifest() remote.Manifest {
+	return remote.Manifest{
+		Version:     "1.0.0",
+		Description: "In-memory reference remote",
+		Schemes:     []string{Type},
+	}
+}
+
+func (m *MemoryRemote) Capabilities() remote.Capabilities {
+	return remote.Capabilities{TagFiltering: true, Delete: true}
+}
+
+func (m *MemoryRemote) Schema(ctx context.Context) (remote.Schema, error) {
+	return schema, nil
+}
+
+func (m *MemoryRemote) FromURL(rawURL string, properties map[string]string) (map[string]interface{}, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, remote.Errorf(remote.ErrInvalidURL, "invalid URL '%s': %v", rawURL, err)
+	}
+	if u.Scheme != Type || u.Host == "" || u.User != nil || u.Port() != "" || (u.Path != "" && u.Path != "/") ||
+		u.RawQuery != "" || u.Fragment != "" {
+		return nil, remote.Errorf(remote.ErrInvalidURL, "URL must be of the form '%s<repository>', got '%s'", scheme,
+			rawURL)
+	}
+	v := &remote.ValidationError{}
+	for k := range properties {
+		v.Add(remote.JoinPath("", k), "unknown property")
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"repository": u.Host}, nil
+}
+
+func (m *MemoryRemote) ToURL(properties map[string]interface{}) (string, map[string]string, error) {
+	repository, err := repositoryName(properties)
+	if err != nil {
+		return "", nil, err
+	}
+	return scheme + repository, map[string]string{}, nil
+}
+
+func (m *MemoryRemote) GetParameters(properties map[string]interface{}) (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
+
+func (m *MemoryRemote) ValidateRemote(properties map[string]interface{}) error {
+	return schema.ValidateRemote(properties)
+}
+
+func (m *MemoryRemote) ValidateParameters(parameters map[string]interface{}) error {
+	return schema.ValidateParameters(parameters)
+}
+
+func (m *MemoryRemote) ListCommits(properties map[string]interface{}, parameters map[string]interface{}, tags []remote.Tag) ([]remote.Commit, error) {
+	repository, err := repositoryName(properties)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]string, 0, len(m.repositories[repository]))
+	for id := range m.repositories[repository] {
+		ids = append(ids, id)
+	}
+	// Sort by ID first, so that commits with the same timestamp are always returned in the same order
+	sort.Strings(ids)
+	ret := []remote.Commit{}
+	for _, id := range ids {
+		c := m.repositories[repository][id]
+		if remote.MatchTags(c.Properties, tags) {
+			ret = append(ret, copyCommit(c))
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return remote.CommitTimestamp(ret[i]).After(remote.CommitTimestamp(ret[j]))
+	})
+	return ret, nil
+}
+
+func (m *MemoryRemote) GetCommit(properties map[string]interface{}, parameters map[string]interface{}, commitId string) (*remote.Commit, error) {
+	repository, err := repositoryName(properties)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.repositories[repository][commitId]
+	if !ok {
+		return nil, nil
+	}
+	ret := copyCommit(c)
+	return &ret, nil
+}
+
+/*
+ * Add a new commit to the repository identified by the given remote properties. Fails with ErrAlreadyExists if the
+ * commit already exists, or ErrInvalidProperty if the commit has no ID or its timestamp is not in RFC 3339 format.
+ */
+func (m *MemoryRemote) AddCommit(properties map[string]interface{}, commit remote.Commit) error {
+	return m.apply(properties, []push{{commit: commit}})
+}
+
+/*
+ * Replace the properties of an existing commit. Fails with ErrNotFound if there is no such commit.
+ */
+func (m *MemoryRemote) UpdateCommit(properties map[string]interface{}, commit remote.Commit) error {
+	return m.apply(properties, []push{{commit: commit, isUpdate: true}})
+}
+
+/*
+ * Delete a commit from the repository. Fails with ErrNotFound if there is no such commit.
+ */
+func (m *MemoryRemote) DeleteCommit(properties map[string]interface{}, commitId string) error {
+	return m.apply(properties, []push{{commit: remote.Commit{Id: commitId}, delete: true}})
+}
+
+/*
+ * Remove all repositories and commits, and abandon any pending operations.
+ */
+func (m *MemoryRemote) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.repositories = map[string]map[string]remote.Commit{}
+	m.pending = map[string][]push{}
+}
+
+func (m *MemoryRemote) StartOperation(ctx context.Context, operation remote.Operation) (map[string]interface{}, error) {
+	repository, err := repositoryName(operation.Remote)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.pending[operation.Id]; ok {
+		return nil, remote.Errorf(remote.ErrAlreadyExists, "operation '%s' is already in progress", operation.Id)
+	}
+	if err := schema.ValidateParameters(operation.Parameters); err != nil {
+		return nil, err
+	}
+	isDelete, _ := operation.Parameters["delete"].(bool)
+	if isDelete && operation.Type != remote.OperationPush {
+		return nil, remote.Errorf(remote.ErrUnsupported, "cannot delete commits in a %s operation", operation.Type)
+	}
+	if operation.Type == remote.OperationPull || isDelete {
+		if _, ok := m.repositories[repository][operation.CommitId]; !ok {
+			return nil, remote.Errorf(remote.ErrNotFound, "no such commit '%s' in repository '%s'", operation.CommitId,
+				repository)
+		}
+	}
+	m.pending[operation.Id] = []push{}
+	if isDelete {
+		m.pending[operation.Id] = []push{{commit: remote.Commit{Id: operation.CommitId}, delete: true}}
+	}
+	return nil, nil
+}
+
+/*
+ * Stage the commit metadata, so that it only becomes visible if the operation ends successfully.
+ */
+func (m *MemoryRemote) PushMetadata(ctx context.Context, operation remote.Operation, commit remote.Commit, isUpdate bool) error {
+	if operation.Type != remote.OperationPush {
+		return remote.Errorf(remote.ErrUnsupported, "cannot push metadata in a %s operation", operation.Type)
+	}
+	if isDelete, _ := operation.Parameters["delete"].(bool); isDelete {
+		return remote.Errorf(remote.ErrUnsupported, "cannot push metadata in an operation that deletes a commit")
+	}
+	if err := validateCommit(commit); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	pushes, ok := m.pending[operation.Id]
+	if !ok {
+		return remote.Errorf(remote.ErrNotFound, "no such operation '%s'", operation.Id)
+	}
+	m.pending[operation.Id] = append(pushes, push{commit: copyCommit(commit), isUpdate: isUpdate})
+	return nil
+}
+
+/*
+ * Volume data is not stored, so syncing only checks that the operation is in progress.
+ */
+func (m *MemoryRemote) SyncVolume(ctx context.Context, operation remote.Operation, volume remote.Volume) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.pending[operation.Id]; !ok {
+		return remote.Errorf(remote.ErrNotFound, "no such operation '%s'", operation.Id)
+	}
+	return nil
+}
+
+func (m *MemoryRemote) EndOperation(ctx context.Context, operation remote.Operation) error {
+	m.mu.Lock()
+	pushes, ok := m.pending[operation.Id]
+	delete(m.pending, operation.Id)
+	m.mu.Unlock()
+	if !ok {
+		return remote.Errorf(remote.ErrNotFound, "no such operation '%s'", operation.Id)
+	}
+	return m.apply(operation.Remote, pushes)
+}
+
+func (m *MemoryRemote) FailOperation(ctx context.Context, operation remote.Operation, reason string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.pending, operation.Id)
+	return nil
+}
+
+/*
+ * Add, update, or delete the given commits, all or nothing.
+ */
+func (m *MemoryRemote) apply(properties map[string]interface{}, pushes []push) error {
+	repository, err := repositoryName(properties)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	commits := m.repositories[repository]
+	for _, p := range pushes {
+		if err := validateCommit(p.commit); err != nil {
+			return err
+		}
+		_, exists := commits[p.commit.Id]
+		if (p.isUpdate || p.delete) && !exists {
+			return remote.Errorf(remote.ErrNotFound, "no such commit '%s' in repository '%s'", p.commit.Id,
+				repository)
+		}
+		if !p.isUpdate && !p.delete && exists {
+			return remote.Errorf(remote.ErrAlreadyExists, "commit '%s' already exists in repository '%s'",
+				p.commit.Id, repository)
+		}
+	}
+	if commits == nil {
+		commits = map[string]remote.Commit{}
+		m.repositories[repository] = commits
+	}
+	for _, p := range pushes {
+		if p.delete {
+			delete(commits, p.commit.Id)
+		} else {
+			commits[p.commit.Id] = copyCommit(p.commit)
+		}
+	}
+	return nil
+}
+
+func repositoryName(properties map[string]interface{}) (string, error) {
+	if err := schema.ValidateRemote(properties); err != nil {
+		return "", err
+	}
+	return properties["repository"].(string), nil
+}
+
+func validateCommit(commit remote.Commit) error {
+	if commit.Id == "" {
+		return remote.Errorf(remote.ErrInvalidProperty, "commit ID must be specified")
+	}
+	if raw, ok := commit.Properties["timestamp"]; ok {
+		s, ok := raw.(string)
+		if !ok {
+			return remote.Errorf(remote.ErrInvalidProperty, "timestamp of commit '%s' must be a string", commit.Id)
+		}
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return remote.Errorf(remote.ErrInvalidProperty, "invalid timestamp '%s' for commit '%s'", s, commit.Id)
+		}
+	}
+	return nil
+}
+
+/*
+ * Copy a commit, so that callers can't modify stored commits through the maps they pass in or get back.
+ */
+func copyCommit(commit remote.Commit) remote.Commit {
+	props, _ := copyValue(commit.Properties).(map[string]interface{})
+	return remote.Commit{Id: commit.Id, Properties: props}
+}
+
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			ret[k] = copyValue(e)
+		}
+		return ret
+	case map[string]string:
+		ret := make(map[string]string, len(v))
+		for k, e := range v {
+			ret[k] = e
+		}
+		return ret
+	case []interface{}:
+		ret := make([]interface{}, len(v))
+		for i, e := range v {
+			ret[i] = copyValue(e)
+		}
+		return ret
+	default:
+		return v
+	}
+}
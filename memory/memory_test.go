@@ -0,0 +1,269 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package memory
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/titan-data/remote-sdk-go/remote"
+	"github.com/titan-data/remote-sdk-go/remotetest"
+	"testing"
+)
+
+var repo = map[string]interface{}{"repository": "repo"}
+
+func commit(id string, timestamp string, tags map[string]interface{}) remote.Commit {
+	return remote.Commit{Id: id, Properties: map[string]interface{}{"timestamp": timestamp, "tags": tags}}
+}
+
+func commitIds(commits []remote.Commit) []string {
+	ret := []string{}
+	for _, c := range commits {
+		ret = append(ret, c.Id)
+	}
+	return ret
+}
+
+func seeded(t *testing.T) *MemoryRemote {
+	m := New()
+	assert.NoError(t, m.AddCommit(repo, commit("a", "2020-01-01T00:00:00Z", map[string]interface{}{"env": "dev"})))
+	assert.NoError(t, m.AddCommit(repo, commit("b", "2020-01-03T00:00:00Z", map[string]interface{}{"env": "prod"})))
+	assert.NoError(t, m.AddCommit(repo, commit("c", "2020-01-02T00:00:00Z", map[string]interface{}{"env": "dev",
+		"release": "1.0"})))
+	return m
+}
+
+func TestConformance(t *testing.T) {
+	prod := "prod"
+	remotetest.Run(t, seeded(t), remotetest.Config{
+		URL:         "memory://repo",
+		CommitId:    "a",
+		Tags:        [][]remote.Tag{{{Key: "env"}}, {{Key: "env", Value: &prod}}, {{Key: "release"}}},
+		InvalidURLs: []string{"s3://repo", "memory://", "memory://repo/path", "memory://user@repo", "memory://repo#c"},
+		InvalidRemotes: []map[string]interface{}{
+			{},
+			{"repository": 1},
+			{"repository": "repo", "bucket": "b"},
+		},
+		InvalidParameters: []map[string]interface{}{{"key": "value"}},
+	})
+}
+
+func TestFromURL(t *testing.T) {
+	m := New()
+	props, err := m.FromURL("memory://repo", map[string]string{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, repo, props)
+	}
+	_, err = m.FromURL("memory://repo", map[string]string{"a": "b"})
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, remote.ErrInvalidProperty))
+		assert.Equal(t, "/a: unknown property", err.Error())
+	}
+}
+
+func TestToURL(t *testing.T) {
+	u, props, err := New().ToURL(repo)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "memory://repo", u)
+		assert.Empty(t, props)
+	}
+}
+
+func TestListCommits(t *testing.T) {
+	m := seeded(t)
+	commits, err := m.ListCommits(repo, nil, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"b", "c", "a"}, commitIds(commits))
+	}
+	dev := "dev"
+	commits, err = m.ListCommits(repo, nil, []remote.Tag{{Key: "env", Value: &dev}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"c", "a"}, commitIds(commits))
+	}
+	commits, err = m.ListCommits(map[string]interface{}{"repository": "other"}, nil, nil)
+	if assert.NoError(t, err) {
+		assert.Empty(t, commits)
+	}
+}
+
+func TestListCommitsSameTimestamp(t *testing.T) {
+	m := New()
+	for _, id := range []string{"c", "a", "b"} {
+		assert.NoError(t, m.AddCommit(repo, commit(id, "2020-01-01T00:00:00Z", nil)))
+	}
+	commits, err := m.ListCommits(repo, nil, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"a", "b", "c"}, commitIds(commits))
+	}
+}
+
+func TestRepositoriesIsolated(t *testing.T) {
+	m := seeded(t)
+	other := map[string]interface{}{"repository": "other"}
+	c, err := m.GetCommit(other, nil, "a")
+	if assert.NoError(t, err) {
+		assert.Nil(t, c)
+	}
+	assert.NoError(t, m.AddCommit(other, commit("a", "2021-01-01T00:00:00Z", nil)))
+	c, err = m.GetCommit(repo, nil, "a")
+	if assert.NoError(t, err) && assert.NotNil(t, c) {
+		assert.Equal(t, "2020-01-01T00:00:00Z", c.Properties["timestamp"])
+	}
+}
+
+func TestAddCommit(t *testing.T) {
+	m := seeded(t)
+	err := m.AddCommit(repo, commit("a", "2020-01-01T00:00:00Z", nil))
+	assert.True(t, errors.Is(err, remote.ErrAlreadyExists))
+	err = m.AddCommit(repo, remote.Commit{})
+	assert.True(t, errors.Is(err, remote.ErrInvalidProperty))
+	err = m.AddCommit(repo, commit("d", "yesterday", nil))
+	if assert.True(t, errors.Is(err, remote.ErrInvalidProperty)) {
+		assert.Equal(t, "invalid timestamp 'yesterday' for commit 'd'", err.Error())
+	}
+	err = m.AddCommit(map[string]interface{}{}, commit("d", "2020-01-01T00:00:00Z", nil))
+	assert.True(t, errors.Is(err, remote.ErrInvalidProperty))
+}
+
+func TestUpdateCommit(t *testing.T) {
+	m := seeded(t)
+	assert.NoError(t, m.UpdateCommit(repo, commit("a", "2020-01-04T00:00:00Z", nil)))
+	commits, err := m.ListCommits(repo, nil, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"a", "b", "c"}, commitIds(commits))
+	}
+	err = m.UpdateCommit(repo, commit("d", "2020-01-04T00:00:00Z", nil))
+	assert.True(t, errors.Is(err, remote.ErrNotFound))
+}
+
+func TestDeleteCommit(t *testing.T) {
+	m := seeded(t)
+	assert.NoError(t, m.DeleteCommit(repo, "b"))
+	c, err := m.GetCommit(repo, nil, "b")
+	if assert.NoError(t, err) {
+		assert.Nil(t, c)
+	}
+	assert.True(t, errors.Is(m.DeleteCommit(repo, "b"), remote.ErrNotFound))
+}
+
+func TestCommitsCopied(t *testing.T) {
+	m := New()
+	c := commit("a", "2020-01-01T00:00:00Z", map[string]interface{}{"env": "dev"})
+	assert.NoError(t, m.AddCommit(repo, c))
+	c.Properties["tags"].(map[string]interface{})["env"] = "prod"
+
+	stored, err := m.GetCommit(repo, nil, "a")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "dev", stored.Properties["tags"].(map[string]interface{})["env"])
+		stored.Properties["timestamp"] = "2021-01-01T00:00:00Z"
+	}
+	stored, err = m.GetCommit(repo, nil, "a")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "2020-01-01T00:00:00Z", stored.Properties["timestamp"])
+	}
+}
+
+func TestReset(t *testing.T) {
+	m := seeded(t)
+	m.Reset()
+	commits, err := m.ListCommits(repo, nil, nil)
+	if assert.NoError(t, err) {
+		assert.Empty(t, commits)
+	}
+}
+
+func testPush(t *testing.T, r remote.Remote) {
+	ctx := context.Background()
+	o, ok := remote.Operations(r)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	op := remote.Operation{Id: "push", Type: remote.OperationPush, CommitId: "a", Remote: repo}
+	_, err := o.StartOperation(ctx, op)
+	if assert.NoError(t, err) {
+		assert.NoError(t, o.PushMetadata(ctx, op, commit("a", "2020-01-01T00:00:00Z", nil), false))
+		assert.NoError(t, o.SyncVolume(ctx, op, remote.Volume{Name: "v0"}))
+		c, err := r.GetCommit(repo, nil, "a")
+		if assert.NoError(t, err) {
+			assert.Nil(t, c, "commit visible before the operation ended")
+		}
+		assert.NoError(t, o.EndOperation(ctx, op))
+	}
+	c, err := r.GetCommit(repo, nil, "a")
+	if assert.NoError(t, err) && assert.NotNil(t, c) {
+		assert.Equal(t, "a", c.Id)
+	}
+
+	op = remote.Operation{Id: "update", Type: remote.OperationPush, CommitId: "a", Remote: repo}
+	_, err = o.StartOperation(ctx, op)
+	if assert.NoError(t, err) {
+		assert.NoError(t, o.PushMetadata(ctx, op, commit("a", "2020-01-02T00:00:00Z", nil), true))
+		assert.NoError(t, o.FailOperation(ctx, op, "failed"))
+	}
+	c, err = r.GetCommit(repo, nil, "a")
+	if assert.NoError(t, err) && assert.NotNil(t, c) {
+		assert.Equal(t, "2020-01-01T00:00:00Z", c.Properties["timestamp"])
+	}
+
+	deleting := map[string]interface{}{"delete": true}
+	op = remote.Operation{Id: "delete", Type: remote.OperationPush, CommitId: "a", Remote: repo, Parameters: deleting}
+	_, err = o.StartOperation(ctx, op)
+	if assert.NoError(t, err) {
+		err = o.PushMetadata(ctx, op, commit("a", "2020-01-02T00:00:00Z", nil), true)
+		assert.True(t, errors.Is(err, remote.ErrUnsupported))
+		c, err := r.GetCommit(repo, nil, "a")
+		if assert.NoError(t, err) {
+			assert.NotNil(t, c, "commit deleted before the operation ended")
+		}
+		assert.NoError(t, o.EndOperation(ctx, op))
+	}
+	c, err = r.GetCommit(repo, nil, "a")
+	if assert.NoError(t, err) {
+		assert.Nil(t, c)
+	}
+	_, err = o.StartOperation(ctx, op)
+	assert.True(t, errors.Is(err, remote.ErrNotFound))
+
+	op = remote.Operation{Id: "pull", Type: remote.OperationPull, CommitId: "b", Remote: repo}
+	_, err = o.StartOperation(ctx, op)
+	assert.True(t, errors.Is(err, remote.ErrNotFound))
+	op.Parameters = deleting
+	_, err = o.StartOperation(ctx, op)
+	assert.True(t, errors.Is(err, remote.ErrUnsupported))
+}
+
+func TestPush(t *testing.T) {
+	testPush(t, New())
+}
+
+func TestPushDuplicate(t *testing.T) {
+	ctx := context.Background()
+	m := seeded(t)
+	op := remote.Operation{Id: "push", Type: remote.OperationPush, CommitId: "a", Remote: repo}
+	_, err := m.StartOperation(ctx, op)
+	if assert.NoError(t, err) {
+		assert.NoError(t, m.PushMetadata(ctx, op, commit("a", "2020-01-01T00:00:00Z", nil), false))
+		assert.True(t, errors.Is(m.EndOperation(ctx, op), remote.ErrAlreadyExists))
+	}
+	assert.True(t, errors.Is(m.EndOperation(ctx, op), remote.ErrNotFound))
+}
+
+func TestPlugin(t *testing.T) {
+	reg := remote.NewRegistry()
+	defer reg.Clear()
+	r, err := reg.Load(Type, "../build")
+	if assert.NoError(t, err) {
+		testPush(t, r)
+		caps, err := remote.GetCapabilities(context.Background(), r)
+		if assert.NoError(t, err) {
+			assert.True(t, caps.Operations)
+			assert.True(t, caps.TagFiltering)
+			assert.True(t, caps.Schema)
+			assert.True(t, caps.Delete)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"errors"
+)
+
+/*
+ * Describes the optional functionality supported natively by a remote. Hosts can use this to decide how to interact
+ * with a remote, rather than guessing and relying on errors or the SDK fallbacks.
+ */
+type Capabilities struct {
+	Cancellation      bool // Implements RemoteWithContext, and can abort in-flight calls
+	Pagination        bool // Implements PaginatedRemote
+	Streaming         bool // Implements IterableRemote
+	TagFiltering      bool // Filters commits by tag server-side (declared via CapabilityReporter)
+	Operations        bool // Implements OperationRemote
+	VolumeTransfer    bool // Implements VolumeTransferRemote
+	ResumableTransfer bool // Implements ResumableTransferRemote
+	Schema            bool // Implements SchemaRemote
+	Delete            bool // Can delete commits, such as through push operations (declared via CapabilityReporter)
+}
+
+/*
+ * Optional interface for remotes to declare capabilities that cannot be detected from the interfaces they implement.
+ * The declared capabilities are combined with the detected ones.
+ */
+type CapabilityReporter interface {
+	Capabilities() Capabilities
+}
+
+/*
+ * Implemented by plugin clients, which query the plugin for its capabilities rather than detecting them.
+ */
+type pluginCapabilities interface {
+	capabilities(ctx context.Context) (Capabilities, error)
+}
+
+/*
+ * Returns the capabilities of the given remote. For remotes loaded as plugins, this queries the plugin. Plugins built
+ * against older versions of the SDK report no capabilities.
+ */
+func GetCapabilities(ctx context.Context, r Remote) (Capabilities, error) {
+	if c, ok := underlying(r).(pluginCapabilities); ok {
+		caps, err := c.capabilities(ctx)
+		if errors.Is(err, ErrUnsupported) {
+			return Capabilities{}, nil
+		}
+		return caps, err
+	}
+	return detectCapabilities(r), nil
+}
+
+func detectCapabilities(r Remote) Capabilities {
+	impl := underlying(r)
+	caps := Capabilities{}
+	if c, ok := impl.(CapabilityReporter); ok {
+		caps = c.Capabilities()
+	}
+	_, ok := impl.(RemoteWithContext)
+	caps.Cancellation = caps.Cancellation || ok
+	_, ok = impl.(PaginatedRemote)
+	caps.Pagination = caps.Pagination || ok
+	_, ok = impl.(IterableRemote)
+	caps.Streaming = caps.Streaming || ok
+	_, ok = impl.(OperationRemote)
+	caps.Operations = caps.Operations || ok
+	_, ok = impl.(VolumeTransferRemote)
+	caps.VolumeTransfer = caps.VolumeTransfer || ok
+	_, ok = impl.(ResumableTransferRemote)
+	caps.ResumableTransfer = caps.ResumableTransfer || ok
+	_, ok = impl.(SchemaRemote)
+	caps.Schema = caps.Schema || ok
+	return caps
+}
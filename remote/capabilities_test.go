@@ -0,0 +1,61 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCapabilitiesNone(t *testing.T) {
+	caps, err := GetCapabilities(context.Background(), new(MockRemote))
+	if assert.NoError(t, err) {
+		assert.Equal(t, Capabilities{}, caps)
+	}
+}
+
+func TestCapabilitiesDetected(t *testing.T) {
+	caps, err := GetCapabilities(context.Background(), nativePagedRemote{new(MockRemote)})
+	if assert.NoError(t, err) {
+		assert.Equal(t, Capabilities{Pagination: true}, caps)
+	}
+}
+
+func TestCapabilitiesContext(t *testing.T) {
+	caps, err := GetCapabilities(context.Background(), WithoutContext(contextRemote{new(MockRemote)}))
+	if assert.NoError(t, err) {
+		assert.False(t, caps.Cancellation)
+	}
+
+	client, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		"remote": &remotePlugin{Impl: WithoutContext(promptingRemote{}), version: ProtocolVersion},
+	})
+	defer server.Stop()
+	defer client.Close()
+	raw, err := client.Dispense("remote")
+	if !assert.NoError(t, err) {
+		return
+	}
+	caps, err = GetCapabilities(context.Background(), WithoutContext(raw.(RemoteWithContext)))
+	if assert.NoError(t, err) {
+		assert.True(t, caps.Cancellation)
+	}
+}
+
+type declaredCapabilitiesRemote struct {
+	*memoryTransferRemote
+}
+
+func (r declaredCapabilitiesRemote) Capabilities() Capabilities {
+	return Capabilities{TagFiltering: true, Delete: true}
+}
+
+func TestCapabilitiesDeclared(t *testing.T) {
+	caps, err := GetCapabilities(context.Background(), declaredCapabilitiesRemote{newMemoryTransferRemote()})
+	if assert.NoError(t, err) {
+		assert.Equal(t, Capabilities{TagFiltering: true, Delete: true, VolumeTransfer: true, ResumableTransfer: true}, caps)
+	}
+}
@@ -0,0 +1,236 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+/*
+ * Progress of a resumable transfer, persisted to disk so that the transfer can pick up where it left off after the
+ * host is interrupted.
+ */
+type Checkpoint struct {
+	Operation string `json:"operation"`
+	Volume    string `json:"volume"`
+	Direction string `json:"direction"`
+	Offset    int64  `json:"offset"`
+}
+
+const (
+	directionUpload   = "upload"
+	directionDownload = "download"
+)
+
+/*
+ * Number of bytes transferred between checkpoints. Uploads are sent as a separate request per interval, so that the
+ * offset committed by the remote is acknowledged and persisted at each step.
+ */
+var checkpointInterval int64 = 64 * 1024 * 1024
+
+/*
+ * Read a checkpoint file, returning nil if it doesn't exist.
+ */
+func ReadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var cp Checkpoint
+	if err = json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint file '%s': %v", path, err)
+	}
+	return &cp, nil
+}
+
+/*
+ * Write a checkpoint file. The file is replaced atomically, so a crash can never leave a partial checkpoint.
+ */
+func WriteCheckpoint(path string, cp Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+/*
+ * Returns the offset recorded in the checkpoint file if it matches the given transfer, or zero otherwise.
+ */
+func checkpointOffset(path string, cp Checkpoint) (int64, bool, error) {
+	existing, err := ReadCheckpoint(path)
+	if err != nil || existing == nil {
+		return 0, false, err
+	}
+	if existing.Operation != cp.Operation || existing.Volume != cp.Volume || existing.Direction != cp.Direction {
+		return 0, false, nil
+	}
+	return existing.Offset, true, nil
+}
+
+func resumableTransfers(r Remote) (ResumableTransferRemote, error) {
+	if t, ok := underlying(r).(ResumableTransferRemote); ok {
+		return t, nil
+	}
+	return nil, Errorf(ErrUnsupported, "remote does not support resumable transfers")
+}
+
+/*
+ * Upload a volume, resuming from the last offset committed by the remote if the checkpoint file shows that a
+ * previous upload for the same operation and volume was interrupted. The checkpoint file is removed once the upload
+ * completes.
+ */
+func ResumableUpload(ctx context.Context, r Remote, operation Operation, volume string, src io.ReadSeeker, checkpointPath string) error {
+	t, err := resumableTransfers(r)
+	if err != nil {
+		return err
+	}
+	cp := Checkpoint{Operation: operation.Id, Volume: volume, Direction: directionUpload}
+	_, resume, err := checkpointOffset(checkpointPath, cp)
+	if err != nil {
+		return err
+	}
+	if resume {
+		if cp.Offset, err = t.VolumeOffset(ctx, operation, volume); err != nil {
+			return err
+		}
+	} else if err = WriteCheckpoint(checkpointPath, cp); err != nil {
+		return err
+	}
+
+	for {
+		if _, err = src.Seek(cp.Offset, io.SeekStart); err != nil {
+			return err
+		}
+		segment := &countingReader{r: io.LimitReader(src, checkpointInterval)}
+		committed, err := t.UploadVolumeAt(ctx, operation, volume, cp.Offset, segment)
+		if err != nil {
+			return err
+		}
+		if segment.n < checkpointInterval {
+			// The remote must have consumed the rest of the source, and committed all of it
+			if n, _ := segment.r.Read(make([]byte, 1)); n != 0 {
+				return Errorf(ErrDataLoss, "remote stopped reading volume '%s' at offset %d", volume,
+					cp.Offset+segment.n)
+			}
+			if committed != cp.Offset+segment.n {
+				return Errorf(ErrDataLoss, "remote committed volume '%s' up to offset %d, but data was sent up to "+
+					"offset %d", volume, committed, cp.Offset+segment.n)
+			}
+			break
+		}
+		if committed <= cp.Offset {
+			return fmt.Errorf("remote did not commit any data for volume '%s' at offset %d", volume, cp.Offset)
+		}
+		cp.Offset = committed
+		if err = WriteCheckpoint(checkpointPath, cp); err != nil {
+			return err
+		}
+	}
+
+	return removeCheckpoint(checkpointPath)
+}
+
+/*
+ * Download a volume, resuming from the last offset recorded in the checkpoint file if a previous download for the
+ * same operation and volume was interrupted. The checkpoint is updated as data is written (after syncing the
+ * destination, if it supports it), and removed once the download completes.
+ */
+func ResumableDownload(ctx context.Context, r Remote, operation Operation, volume string, dst io.WriteSeeker, checkpointPath string) error {
+	t, err := resumableTransfers(r)
+	if err != nil {
+		return err
+	}
+	cp := Checkpoint{Operation: operation.Id, Volume: volume, Direction: directionDownload}
+	if cp.Offset, _, err = checkpointOffset(checkpointPath, cp); err != nil {
+		return err
+	}
+	if _, err = dst.Seek(cp.Offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	w := &checkpointWriter{w: dst, path: checkpointPath, cp: cp, saved: cp.Offset}
+	if err = t.DownloadVolumeAt(ctx, operation, volume, cp.Offset, w); err != nil {
+		w.save()
+		return err
+	}
+	return removeCheckpoint(checkpointPath)
+}
+
+func removeCheckpoint(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+type checkpointWriter struct {
+	w     io.Writer
+	path  string
+	cp    Checkpoint
+	saved int64
+}
+
+func (c *checkpointWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.cp.Offset += int64(n)
+	if err != nil {
+		return n, err
+	}
+	if c.cp.Offset-c.saved >= checkpointInterval {
+		if err = c.save(); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (c *checkpointWriter) save() error {
+	if s, ok := c.w.(interface{ Sync() error }); ok {
+		if err := s.Sync(); err != nil {
+			return err
+		}
+	}
+	if err := WriteCheckpoint(c.path, c.cp); err != nil {
+		return err
+	}
+	c.saved = c.cp.Offset
+	return nil
+}
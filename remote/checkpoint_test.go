@@ -0,0 +1,203 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type memoryTransferRemote struct {
+	*MockRemote
+	volumes   map[string][]byte
+	uploads   int
+	failAfter int
+}
+
+func newMemoryTransferRemote() *memoryTransferRemote {
+	return &memoryTransferRemote{MockRemote: new(MockRemote), volumes: map[string][]byte{}, failAfter: -1}
+}
+
+func (m *memoryTransferRemote) UploadVolume(ctx context.Context, operation Operation, volume string, r io.Reader) error {
+	_, err := m.UploadVolumeAt(ctx, operation, volume, 0, r)
+	return err
+}
+
+func (m *memoryTransferRemote) DownloadVolume(ctx context.Context, operation Operation, volume string, w io.Writer) error {
+	return m.DownloadVolumeAt(ctx, operation, volume, 0, w)
+}
+
+func (m *memoryTransferRemote) VolumeOffset(ctx context.Context, operation Operation, volume string) (int64, error) {
+	return int64(len(m.volumes[volume])), nil
+}
+
+func (m *memoryTransferRemote) UploadVolumeAt(ctx context.Context, operation Operation, volume string, offset int64, r io.Reader) (int64, error) {
+	if m.failAfter >= 0 && m.uploads >= m.failAfter {
+		return 0, errors.New("connection lost")
+	}
+	m.uploads++
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	m.volumes[volume] = append(m.volumes[volume][:offset], data...)
+	return int64(len(m.volumes[volume])), nil
+}
+
+func (m *memoryTransferRemote) DownloadVolumeAt(ctx context.Context, operation Operation, volume string, offset int64, w io.Writer) error {
+	data := m.volumes[volume][offset:]
+	for len(data) > 0 {
+		n := 10
+		if n > len(data) {
+			n = len(data)
+		}
+		if m.failAfter == 0 {
+			return errors.New("connection lost")
+		}
+		m.failAfter--
+		if _, err := w.Write(data[:n]); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+func withCheckpointInterval(t *testing.T, interval int64) (string, func()) {
+	dir, err := ioutil.TempDir("", "checkpoint")
+	assert.NoError(t, err)
+	prev := checkpointInterval
+	checkpointInterval = interval
+	return filepath.Join(dir, "checkpoint.json"), func() {
+		checkpointInterval = prev
+		os.RemoveAll(dir)
+	}
+}
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	path, cleanup := withCheckpointInterval(t, 10)
+	defer cleanup()
+	cp := Checkpoint{Operation: "op", Volume: "vol", Direction: directionUpload, Offset: 42}
+	assert.NoError(t, WriteCheckpoint(path, cp))
+	res, err := ReadCheckpoint(path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, cp, *res)
+	}
+}
+
+func TestReadMissingCheckpoint(t *testing.T) {
+	path, cleanup := withCheckpointInterval(t, 10)
+	defer cleanup()
+	res, err := ReadCheckpoint(path)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestResumableUpload(t *testing.T) {
+	path, cleanup := withCheckpointInterval(t, 10)
+	defer cleanup()
+	data := make([]byte, 35)
+	rand.Read(data)
+	m := newMemoryTransferRemote()
+	assert.NoError(t, ResumableUpload(context.Background(), m, Operation{Id: "op"}, "vol", bytes.NewReader(data), path))
+	assert.Equal(t, data, m.volumes["vol"])
+	assert.Equal(t, 4, m.uploads)
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestResumableUploadInterrupted(t *testing.T) {
+	path, cleanup := withCheckpointInterval(t, 10)
+	defer cleanup()
+	data := make([]byte, 35)
+	rand.Read(data)
+	m := newMemoryTransferRemote()
+	m.failAfter = 2
+	assert.Error(t, ResumableUpload(context.Background(), m, Operation{Id: "op"}, "vol", bytes.NewReader(data), path))
+	cp, err := ReadCheckpoint(path)
+	if assert.NoError(t, err) && assert.NotNil(t, cp) {
+		assert.Equal(t, int64(20), cp.Offset)
+	}
+
+	m.failAfter = -1
+	assert.NoError(t, ResumableUpload(context.Background(), m, Operation{Id: "op"}, "vol", bytes.NewReader(data), path))
+	assert.Equal(t, data, m.volumes["vol"])
+	assert.Equal(t, 4, m.uploads)
+}
+
+/*
+ * A remote that reads only part of each upload, or commits less than it reads.
+ */
+type lossyTransferRemote struct {
+	*memoryTransferRemote
+	readLimit int64
+	lost      int64
+}
+
+func (m lossyTransferRemote) UploadVolumeAt(ctx context.Context, operation Operation, volume string, offset int64, r io.Reader) (int64, error) {
+	committed, err := m.memoryTransferRemote.UploadVolumeAt(ctx, operation, volume, offset, io.LimitReader(r, m.readLimit))
+	return committed - m.lost, err
+}
+
+func TestResumableUploadDataLoss(t *testing.T) {
+	path, cleanup := withCheckpointInterval(t, 10)
+	defer cleanup()
+	data := make([]byte, 35)
+	rand.Read(data)
+
+	m := lossyTransferRemote{memoryTransferRemote: newMemoryTransferRemote(), readLimit: 10, lost: 1}
+	err := ResumableUpload(context.Background(), m, Operation{Id: "op"}, "vol", bytes.NewReader(data), path)
+	if assert.True(t, errors.Is(err, ErrDataLoss)) {
+		assert.Equal(t, "remote committed volume 'vol' up to offset 34, but data was sent up to offset 35", err.Error())
+	}
+
+	m = lossyTransferRemote{memoryTransferRemote: newMemoryTransferRemote(), readLimit: 5}
+	err = ResumableUpload(context.Background(), m, Operation{Id: "op2"}, "vol", bytes.NewReader(data), path)
+	if assert.True(t, errors.Is(err, ErrDataLoss)) {
+		assert.Equal(t, "remote stopped reading volume 'vol' at offset 5", err.Error())
+	}
+}
+
+func TestResumableDownloadInterrupted(t *testing.T) {
+	path, cleanup := withCheckpointInterval(t, 10)
+	defer cleanup()
+	data := make([]byte, 35)
+	rand.Read(data)
+	m := newMemoryTransferRemote()
+	m.volumes["vol"] = data
+	m.failAfter = 2
+
+	dst, err := ioutil.TempFile(filepath.Dir(path), "volume")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer dst.Close()
+	assert.Error(t, ResumableDownload(context.Background(), m, Operation{Id: "op"}, "vol", dst, path))
+	cp, err := ReadCheckpoint(path)
+	if assert.NoError(t, err) && assert.NotNil(t, cp) {
+		assert.Equal(t, int64(20), cp.Offset)
+	}
+
+	m.failAfter = -1
+	assert.NoError(t, ResumableDownload(context.Background(), m, Operation{Id: "op"}, "vol", dst, path))
+	out, err := ioutil.ReadFile(dst.Name())
+	if assert.NoError(t, err) {
+		assert.Equal(t, data, out)
+	}
+}
+
+func TestResumableUnsupported(t *testing.T) {
+	path, cleanup := withCheckpointInterval(t, 10)
+	defer cleanup()
+	err := ResumableUpload(context.Background(), new(MockRemote), Operation{Id: "op"}, "vol", bytes.NewReader([]byte{}), path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+)
+
+/*
+ * Context-aware variant of the Remote interface. Each method has the same semantics as its Remote counterpart, but
+ * takes a context whose deadline and cancellation are honored across the plugin boundary. Remotes that can abort
+ * in-flight work (such as network requests) should implement this interface and register via RegisterWithContext().
+ */
+type RemoteWithContext interface {
+	Type(ctx context.Context) (string, error)
+	FromURL(ctx context.Context, url string, properties map[string]string) (map[string]interface{}, error)
+	ToURL(ctx context.Context, properties map[string]interface{}) (string, map[string]string, error)
+	GetParameters(ctx context.Context, properties map[string]interface{}) (map[string]interface{}, error)
+	ValidateRemote(ctx context.Context, properties map[string]interface{}) error
+	ValidateParameters(ctx context.Context, parameters map[string]interface{}) error
+	ListCommits(ctx context.Context, properties map[string]interface{}, parameters map[string]interface{}, tags []Tag) ([]Commit, error)
+	GetCommit(ctx context.Context, properties map[string]interface{}, parameters map[string]interface{}, commitId string) (*Commit, error)
+}
+
+/*
+ * Adapts a Remote to the RemoteWithContext interface. If the remote was itself produced by WithoutContext(), the
+ * original context-aware remote is returned. Otherwise, each call is run in the background and abandoned as soon as
+ * the context is done, so that callers are never blocked on a hung remote past their deadline.
+ */
+func WithContext(r Remote) RemoteWithContext {
+	if r == nil {
+		return nil
+	}
+	if w, ok := r.(contextlessRemote); ok {
+		return w.impl
+	}
+	return contextRemote{impl: r}
+}
+
+/*
+ * Adapts a RemoteWithContext to the Remote interface, using a background context for every call.
+ */
+func WithoutContext(r RemoteWithContext) Remote {
+	if r == nil {
+		return nil
+	}
+	if w, ok := r.(contextRemote); ok {
+		return w.impl
+	}
+	return contextlessRemote{impl: r}
+}
+
+/*
+ * Register a new context-aware remote. This is equivalent to Register(), but allows the remote to receive contexts
+ * when invoked through WithContext() or through the plugin interface.
+ */
+func RegisterWithContext(remote RemoteWithContext) {
+	defaultRegistry.RegisterWithContext(remote)
+}
+
+/*
+ * Returns the implementation behind any adapters, so that optional interfaces can be detected by type assertion.
+ */
+func underlying(r interface{}) interface{} {
+	switch w := r.(type) {
+	case contextRemote:
+		return w.impl
+	case contextlessRemote:
+		return underlying(w.impl)
+	default:
+		return r
+	}
+}
+
+/*
+ * Run the given function in the background, returning early with the context error if the context is done first.
+ * Results must only be read by the caller when this returns the error from the function itself.
+ */
+func runWithContext(ctx context.Context, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type contextRemote struct {
+	impl Remote
+}
+
+func (r contextRemote) Type(ctx context.Context) (string, error) {
+	var typ string
+	err := runWithContext(ctx, func() error {
+		var err error
+		typ, err = r.impl.Type()
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return typ, nil
+}
+
+func (r contextRemote) FromURL(ctx context.Context, url string, properties map[string]string) (map[string]interface{}, error) {
+	var props map[string]interface{}
+	err := runWithContext(ctx, func() error {
+		var err error
+		props, err = r.impl.FromURL(url, properties)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return props, nil
+}
+
+func (r contextRemote) ToURL(ctx context.Context, properties map[string]interface{}) (string, map[string]string, error) {
+	var url string
+	var props map[string]string
+	err := runWithContext(ctx, func() error {
+		var err error
+		url, props, err = r.impl.ToURL(properties)
+		return err
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return url, props, nil
+}
+
+func (r contextRemote) GetParameters(ctx context.Context, properties map[string]interface{}) (map[string]interface{}, error) {
+	var params map[string]interface{}
+	err := runWithContext(ctx, func() error {
+		var err error
+		params, err = r.impl.GetParameters(properties)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func (r contextRemote) ValidateRemote(ctx context.Context, properties map[string]interface{}) error {
+	return runWithContext(ctx, func() error {
+		return r.impl.ValidateRemote(properties)
+	})
+}
+
+func (r contextRemote) ValidateParameters(ctx context.Context, parameters map[string]interface{}) error {
+	return runWithContext(ctx, func() error {
+		return r.impl.ValidateParameters(parameters)
+	})
+}
+
+func (r contextRemote) ListCommits(ctx context.Context, properties map[string]interface{}, parameters map[string]interface{}, tags []Tag) ([]Commit, error) {
+	var commits []Commit
+	err := runWithContext(ctx, func() error {
+		var err error
+		commits, err = r.impl.ListCommits(properties, parameters, tags)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
+
+func (r contextRemote) GetCommit(ctx context.Context, properties map[string]interface{}, parameters map[string]interface{}, commitId string) (*Commit, error) {
+	var commit *Commit
+	err := runWithContext(ctx, func() error {
+		var err error
+		commit, err = r.impl.GetCommit(properties, parameters, commitId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commit, nil
+}
+
+type contextlessRemote struct {
+	impl RemoteWithContext
+}
+
+func (r contextlessRemote) Type() (string, error) {
+	return r.impl.Type(context.Background())
+}
+
+func (r contextlessRemote) FromURL(url string, properties map[string]string) (map[string]interface{}, error) {
+	return r.impl.FromURL(context.Background(), url, properties)
+}
+
+func (r contextlessRemote) ToURL(properties map[string]interface{}) (string, map[string]string, error) {
+	return r.impl.ToURL(context.Background(), properties)
+}
+
+func (r contextlessRemote) GetParameters(properties map[string]interface{}) (map[string]interface{}, error) {
+	return r.impl.GetParameters(context.Background(), properties)
+}
+
+func (r contextlessRemote) ValidateRemote(properties map[string]interface{}) error {
+	return r.impl.ValidateRemote(context.Background(), properties)
+}
+
+func (r contextlessRemote) ValidateParameters(parameters map[string]interface{}) error {
+	return r.impl.ValidateParameters(context.Background(), parameters)
+}
+
+func (r contextlessRemote) ListCommits(properties map[string]interface{}, parameters map[string]interface{}, tags []Tag) ([]Commit, error) {
+	return r.impl.ListCommits(context.Background(), properties, parameters, tags)
+}
+
+func (r contextlessRemote) GetCommit(properties map[string]interface{}, parameters map[string]interface{}, commitId string) (*Commit, error) {
+	return r.impl.GetCommit(context.Background(), properties, parameters, commitId)
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestWithContext(t *testing.T) {
+	r := new(MockRemote)
+	r.On("Type").Return("mock")
+	typ, err := WithContext(r).Type(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "mock", typ)
+	}
+	r.AssertExpectations(t)
+}
+
+func TestWithContextRoundTrip(t *testing.T) {
+	r := new(MockRemote)
+	assert.Equal(t, r, WithoutContext(WithContext(r)))
+	assert.Equal(t, r, underlying(WithContext(r)))
+}
+
+func TestWithContextCanceled(t *testing.T) {
+	r := new(MockRemote)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := WithContext(r).Type(ctx)
+	assert.Equal(t, context.Canceled, err)
+	r.AssertNotCalled(t, "Type")
+}
+
+func TestWithContextDeadline(t *testing.T) {
+	block := make(chan time.Time)
+	defer close(block)
+	r := new(MockRemote)
+	r.On("Type").Return("mock").WaitUntil(block)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := WithContext(r).Type(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestRegisterWithContext(t *testing.T) {
+	Clear()
+	r := new(MockRemote)
+	r.On("Type").Return("mock")
+	RegisterWithContext(WithContext(r))
+	assert.Equal(t, r, Get("mock"))
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+/*
+ * Environment variable holding a list of directories to search for plugins, separated by colons (semicolons on
+ * Windows). These directories are searched before the default directories.
+ */
+const PluginPathEnv = "TITAN_REMOTE_PLUGIN_PATH"
+
+/*
+ * Prefix of plugin binaries found through discovery. A plugin for the "s3" remote is named "titan-remote-s3".
+ */
+const PluginPrefix = "titan-remote-"
+
+/*
+ * Returned when a remote type is not a valid name, such as one containing path separators. Remote types are used as
+ * URL schemes and plugin file names, so they must be lowercase letters, digits, '+', '.' or '-', starting with a
+ * letter or digit.
+ */
+var ErrInvalidType = errors.New("invalid remote type")
+
+var remoteTypePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9+.-]*$`)
+
+/*
+ * Check that a remote type is safe to use within a file name, before looking for its plugin.
+ */
+func validateType(remoteType string) error {
+	if !remoteTypePattern.MatchString(remoteType) {
+		return Errorf(ErrInvalidType, "invalid remote type '%s'", remoteType)
+	}
+	return nil
+}
+
+/*
+ * A plugin binary found on the search path, along with its manifest if it has one.
+ */
+type Plugin struct {
+	Type     string
+	Path     string
+	Manifest *Manifest
+}
+
+/*
+ * Returns the directories searched for plugins by default: those listed in TITAN_REMOTE_PLUGIN_PATH, followed by
+ * ~/.titan/remotes and /usr/local/lib/titan/remotes.
+ */
+func DefaultSearchPath() []string {
+	var ret []string
+	for _, dir := range filepath.SplitList(os.Getenv(PluginPathEnv)) {
+		if dir != "" {
+			ret = append(ret, dir)
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		ret = append(ret, filepath.Join(home, ".titan", "remotes"))
+	}
+	return append(ret, "/usr/local/lib/titan/remotes")
+}
+
+/*
+ * Find all plugins within the given directories, sorted by type. If the same type is found in more than one
+ * directory, the first directory in the search path takes precedence. Directories that don't exist are ignored.
+ */
+func Discover(searchPath []string) []Plugin {
+	found := map[string]Plugin{}
+	for _, dir := range searchPath {
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			remoteType := strings.TrimPrefix(e.Name(), PluginPrefix)
+			if remoteType == e.Name() || validateType(remoteType) != nil || isCompanion(e.Name()) {
+				continue
+			}
+			if _, ok := found[remoteType]; ok {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			if isExecutable(path) {
+				found[remoteType] = newPlugin(remoteType, path)
+			}
+		}
+	}
+
+	ret := make([]Plugin, 0, len(found))
+	for _, p := range found {
+		ret = append(ret, p)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Type < ret[j].Type
+	})
+	return ret
+}
+
+/*
+ * Find the plugin for the given remote type within the given directories, failing with ErrNotFound if there is none,
+ * or ErrInvalidType if the type is not a valid name.
+ */
+func FindPlugin(searchPath []string, remoteType string) (Plugin, error) {
+	if err := validateType(remoteType); err != nil {
+		return Plugin{}, err
+	}
+	for _, dir := range searchPath {
+		path := filepath.Join(dir, PluginPrefix+remoteType)
+		if isExecutable(path) && !isCompanion(path) {
+			return newPlugin(remoteType, path), nil
+		}
+	}
+	return Plugin{}, Errorf(ErrNotFound, "no plugin for remote '%s' found in %s", remoteType,
+		strings.Join(searchPath, string(filepath.ListSeparator)))
+}
+
+/*
+ * Returns whether the file is a manifest or signature stored alongside a plugin binary. Some filesystems mark every
+ * file as executable, so these can't be told apart from plugins by their mode.
+ */
+func isCompanion(name string) bool {
+	return strings.HasSuffix(name, ManifestPath("")) || strings.HasSuffix(name, SignaturePath(""))
+}
+
+/*
+ * Describe a plugin, ignoring any problems reading its manifest until it is loaded.
+ */
+func newPlugin(remoteType string, path string) Plugin {
+	m, _ := ReadManifest(path)
+	return Plugin{Type: remoteType, Path: path, Manifest: m}
+}
+
+/*
+ * Returns the path of the plugin binary for the given type within a single directory. Binaries named after the
+ * remote type are preferred, for compatibility with plugins built before discovery, followed by the discovery
+ * naming convention. Fails with ErrInvalidType if the type is not a valid name.
+ */
+func pluginBinary(pluginPath string, remoteType string) (string, error) {
+	if err := validateType(remoteType); err != nil {
+		return "", err
+	}
+	path := filepath.Join(pluginPath, remoteType)
+	if isExecutable(path) {
+		return path, nil
+	}
+	if p, err := FindPlugin([]string{pluginPath}, remoteType); err == nil {
+		return p.Path, nil
+	}
+	return path, nil
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular() && info.Mode().Perm()&0111 != 0
+}
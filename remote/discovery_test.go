@@ -0,0 +1,181 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func tempPluginDir(t *testing.T, files map[string]os.FileMode) string {
+	dir, err := ioutil.TempDir("", "plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, mode := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte{}, mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestDiscover(t *testing.T) {
+	first := tempPluginDir(t, map[string]os.FileMode{
+		"titan-remote-s3":  0755,
+		"titan-remote-ssh": 0644,
+		"other":            0755,
+	})
+	defer os.RemoveAll(first)
+	second := tempPluginDir(t, map[string]os.FileMode{
+		"titan-remote-s3":  0755,
+		"titan-remote-ssh": 0755,
+	})
+	defer os.RemoveAll(second)
+
+	plugins := Discover([]string{first, "/nonexistent", second})
+	assert.Equal(t, []Plugin{
+		{Type: "s3", Path: filepath.Join(first, "titan-remote-s3")},
+		{Type: "ssh", Path: filepath.Join(second, "titan-remote-ssh")},
+	}, plugins)
+}
+
+func TestDiscoverEmpty(t *testing.T) {
+	assert.Empty(t, Discover(nil))
+}
+
+func TestFindPlugin(t *testing.T) {
+	dir := tempPluginDir(t, map[string]os.FileMode{"titan-remote-s3": 0755})
+	defer os.RemoveAll(dir)
+
+	p, err := FindPlugin([]string{"/nonexistent", dir}, "s3")
+	if assert.NoError(t, err) {
+		assert.Equal(t, Plugin{Type: "s3", Path: filepath.Join(dir, "titan-remote-s3")}, p)
+	}
+
+	_, err = FindPlugin([]string{dir}, "ssh")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestDefaultSearchPath(t *testing.T) {
+	old, set := os.LookupEnv(PluginPathEnv)
+	defer func() {
+		if set {
+			os.Setenv(PluginPathEnv, old)
+		} else {
+			os.Unsetenv(PluginPathEnv)
+		}
+	}()
+
+	os.Setenv(PluginPathEnv, "/a:/b")
+	path := DefaultSearchPath()
+	assert.Equal(t, []string{"/a", "/b"}, path[:2])
+	assert.Equal(t, "/usr/local/lib/titan/remotes", path[len(path)-1])
+
+	os.Unsetenv(PluginPathEnv)
+	assert.Equal(t, "/usr/local/lib/titan/remotes", DefaultSearchPath()[len(DefaultSearchPath())-1])
+}
+
+func TestRegistrySearchPath(t *testing.T) {
+	assert.Equal(t, []string{"/a"}, NewRegistry(WithSearchPath("/a")).SearchPath())
+	assert.Equal(t, DefaultSearchPath(), NewRegistry().SearchPath())
+}
+
+func linkEcho(t *testing.T, name string) string {
+	dir, err := ioutil.TempDir("", "plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	echo, err := filepath.Abs("../build/echo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(echo, filepath.Join(dir, name)); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestRegistryLazyLoad(t *testing.T) {
+	dir := linkEcho(t, "titan-remote-echo")
+	defer os.RemoveAll(dir)
+	reg := NewRegistry(WithSearchPath(dir))
+	defer reg.Clear()
+
+	assert.Equal(t, []Plugin{{Type: "echo", Path: filepath.Join(dir, "titan-remote-echo")}}, reg.Discover())
+	r := reg.Get("echo")
+	if assert.NotNil(t, r) {
+		typ, err := r.Type()
+		if assert.NoError(t, err) {
+			assert.Equal(t, "echo", typ)
+		}
+		assert.Equal(t, []string{"echo"}, reg.List())
+		assert.Equal(t, r, reg.Get("echo"))
+	}
+}
+
+func TestRegistryLookupMissing(t *testing.T) {
+	reg := NewRegistry(WithSearchPath("/nonexistent"))
+	_, err := reg.Lookup("echo")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Nil(t, reg.Get("echo"))
+}
+
+func TestRegistryLoadConvention(t *testing.T) {
+	dir := linkEcho(t, "titan-remote-echo")
+	defer os.RemoveAll(dir)
+	reg := NewRegistry()
+	defer reg.Clear()
+
+	r, err := reg.Load("echo", dir)
+	if assert.NoError(t, err) {
+		typ, err := r.Type()
+		if assert.NoError(t, err) {
+			assert.Equal(t, "echo", typ)
+		}
+	}
+}
+
+func TestInvalidType(t *testing.T) {
+	for _, typ := range []string{"", "../../../../../../usr/bin/id", "/usr/bin/id", "a/b", "S3", ".hidden", "-x"} {
+		_, err := FindPlugin([]string{"/usr/bin"}, typ)
+		assert.True(t, errors.Is(err, ErrInvalidType), "FindPlugin accepted '%s'", typ)
+		_, err = pluginBinary("/usr/bin", typ)
+		assert.True(t, errors.Is(err, ErrInvalidType), "pluginBinary accepted '%s'", typ)
+	}
+	for _, typ := range []string{"s3", "ssh", "git+ssh", "nop.v2", "my-remote"} {
+		assert.NoError(t, validateType(typ))
+	}
+}
+
+func TestLookupInvalidType(t *testing.T) {
+	reg := NewRegistry(WithSearchPath("/usr/bin"))
+	_, err := reg.Lookup("../../../../../../usr/bin/id")
+	assert.True(t, errors.Is(err, ErrInvalidType))
+	_, err = reg.Load("../bin/id", "/usr/bin")
+	assert.True(t, errors.Is(err, ErrInvalidType))
+	assert.Empty(t, reg.List())
+}
+
+func TestDiscoverInvalidType(t *testing.T) {
+	dir := tempPluginDir(t, map[string]os.FileMode{"titan-remote-S3": 0755, "titan-remote-": 0755})
+	defer os.RemoveAll(dir)
+	assert.Empty(t, Discover([]string{dir}))
+}
+
+func TestDiscoverCompanionFiles(t *testing.T) {
+	dir := tempPluginDir(t, map[string]os.FileMode{
+		"titan-remote-s3":      0755,
+		"titan-remote-s3.json": 0755,
+		"titan-remote-s3.sig":  0755,
+	})
+	defer os.RemoveAll(dir)
+	assert.Equal(t, []Plugin{{Type: "s3", Path: filepath.Join(dir, "titan-remote-s3")}}, Discover([]string{dir}))
+	_, err := FindPlugin([]string{dir}, "s3.json")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
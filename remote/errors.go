@@ -0,0 +1,170 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	proto "github.com/titan-data/remote-sdk-go/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/*
+ * Kinds of errors that can be returned by remotes. Remotes should return errors that wrap one of these (either via
+ * Errorf() or fmt.Errorf("...: %w", ErrNotFound)), so that callers can distinguish them with errors.Is(). The kind
+ * is preserved across the plugin boundary.
+ */
+var (
+	ErrNotFound         = errors.New("not found")
+	ErrAlreadyExists    = errors.New("already exists")
+	ErrInvalidURL       = errors.New("invalid URL")
+	ErrInvalidProperty  = errors.New("invalid property")
+	ErrUnauthenticated  = errors.New("unauthenticated")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrUnavailable      = errors.New("unavailable")
+	ErrUnsupported      = errors.New("unsupported")
+	ErrDataLoss         = errors.New("data loss")
+)
+
+/*
+ * An error of a specific kind, with a descriptive message.
+ */
+type Error struct {
+	Kind    error
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+/*
+ * Create a new error of the given kind, with a formatted message.
+ */
+func Errorf(kind error, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+type errorKind struct {
+	name string
+	kind error
+	code codes.Code
+}
+
+var errorKinds = []errorKind{
+	{"not_found", ErrNotFound, codes.NotFound},
+	{"already_exists", ErrAlreadyExists, codes.AlreadyExists},
+	{"invalid_url", ErrInvalidURL, codes.InvalidArgument},
+	{"invalid_property", ErrInvalidProperty, codes.InvalidArgument},
+	{"unauthenticated", ErrUnauthenticated, codes.Unauthenticated},
+	{"permission_denied", ErrPermissionDenied, codes.PermissionDenied},
+	{"unavailable", ErrUnavailable, codes.Unavailable},
+	{"unsupported", ErrUnsupported, codes.Unimplemented},
+	{"data_loss", ErrDataLoss, codes.DataLoss},
+}
+
+/*
+ * Convert an error returned by a remote into a gRPC status error, encoding its kind as a status code and detail.
+ */
+func encodeError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, err.Error())
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	for _, k := range errorKinds {
+		if errors.Is(err, k.kind) {
+			detail := &proto.ErrorDetail{Kind: k.name, Message: err.Error()}
+			var v *ValidationError
+			if errors.As(err, &v) {
+				for _, f := range v.Errors {
+					detail.Violations = append(detail.Violations, &proto.FieldViolation{Path: f.Path, Message: f.Message})
+				}
+			}
+			s, detailErr := status.New(k.code, err.Error()).WithDetails(detail)
+			if detailErr != nil {
+				return status.Error(k.code, err.Error())
+			}
+			return s.Err()
+		}
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+/*
+ * Convert a gRPC status error back into the error kinds above. Validation errors are restored with all of their field
+ * errors, along with any context the remote wrapped them in. Errors that aren't gRPC status errors, such as io.EOF,
+ * are returned unmodified.
+ */
+func decodeError(err error) error {
+	if err == nil {
+		return nil
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for _, d := range s.Details() {
+		if detail, ok := d.(*proto.ErrorDetail); ok {
+			if len(detail.Violations) != 0 {
+				v := &ValidationError{}
+				for _, f := range detail.Violations {
+					v.Errors = append(v.Errors, FieldError{Path: f.Path, Message: f.Message})
+				}
+				return withMessage(v, detail.Message)
+			}
+			for _, k := range errorKinds {
+				if k.name == detail.Kind {
+					return &Error{Kind: k.kind, Message: detail.Message}
+				}
+			}
+		}
+	}
+	switch s.Code() {
+	case codes.Canceled:
+		return context.Canceled
+	case codes.DeadlineExceeded:
+		return context.DeadlineExceeded
+	case codes.NotFound:
+		return &Error{Kind: ErrNotFound, Message: s.Message()}
+	case codes.AlreadyExists:
+		return &Error{Kind: ErrAlreadyExists, Message: s.Message()}
+	case codes.Unauthenticated:
+		return &Error{Kind: ErrUnauthenticated, Message: s.Message()}
+	case codes.PermissionDenied:
+		return &Error{Kind: ErrPermissionDenied, Message: s.Message()}
+	case codes.Unavailable:
+		return &Error{Kind: ErrUnavailable, Message: s.Message()}
+	case codes.Unimplemented:
+		return &Error{Kind: ErrUnsupported, Message: s.Message()}
+	case codes.DataLoss:
+		return &Error{Kind: ErrDataLoss, Message: s.Message()}
+	default:
+		return errors.New(s.Message())
+	}
+}
+
+/*
+ * Returns the validation error with the given message, which includes any context it was wrapped in. The field errors
+ * remain available through errors.As().
+ */
+func withMessage(v *ValidationError, msg string) error {
+	if msg == "" || msg == v.Error() {
+		return v
+	}
+	return &Error{Kind: v, Message: msg}
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"testing"
+)
+
+func TestErrorf(t *testing.T) {
+	err := Errorf(ErrNotFound, "no such commit '%s'", "foo")
+	assert.Equal(t, "no such commit 'foo'", err.Error())
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, errors.Is(err, ErrUnavailable))
+}
+
+func TestErrorRoundTrip(t *testing.T) {
+	for _, k := range errorKinds {
+		err := decodeError(encodeError(Errorf(k.kind, "error %s", k.name)))
+		assert.True(t, errors.Is(err, k.kind), k.name)
+		assert.Equal(t, "error "+k.name, err.Error())
+	}
+}
+
+func TestErrorRoundTripWrapped(t *testing.T) {
+	err := decodeError(encodeError(fmt.Errorf("bucket 'foo': %w", ErrPermissionDenied)))
+	assert.True(t, errors.Is(err, ErrPermissionDenied))
+	assert.Equal(t, "bucket 'foo': permission denied", err.Error())
+}
+
+func TestErrorEncodeCode(t *testing.T) {
+	assert.Equal(t, codes.NotFound, status.Code(encodeError(Errorf(ErrNotFound, "missing"))))
+	assert.Equal(t, codes.InvalidArgument, status.Code(encodeError(Errorf(ErrInvalidURL, "bad url"))))
+	assert.Equal(t, codes.Unknown, status.Code(encodeError(errors.New("error"))))
+	assert.Nil(t, encodeError(nil))
+}
+
+func TestErrorContext(t *testing.T) {
+	assert.Equal(t, context.Canceled, decodeError(encodeError(context.Canceled)))
+	assert.Equal(t, context.DeadlineExceeded, decodeError(encodeError(context.DeadlineExceeded)))
+}
+
+func TestErrorDecodeWithoutDetails(t *testing.T) {
+	err := decodeError(status.Error(codes.Unimplemented, "unknown method"))
+	assert.True(t, errors.Is(err, ErrUnsupported))
+	err = decodeError(status.Error(codes.Unknown, "some error"))
+	assert.Equal(t, "some error", err.Error())
+}
+
+func TestErrorDecodeNonStatus(t *testing.T) {
+	assert.Equal(t, io.EOF, decodeError(io.EOF))
+	assert.Nil(t, decodeError(nil))
+}
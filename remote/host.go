@@ -0,0 +1,286 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	proto "github.com/titan-data/remote-sdk-go/internal/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"strconv"
+	"sync"
+)
+
+/*
+ * Services provided by the host to the plugin, such as progress reporting and interactive prompts. These are served
+ * over the plugin broker, and the broker ID is passed to the plugin as metadata on each call that needs them, along
+ * with a call ID that identifies the context of the originating call and the services attached to that context.
+ */
+const (
+	hostIdKey       = "titan-host-id"
+	callIdKey       = "titan-call-id"
+	hostServicesKey = "titan-host-services"
+)
+
+const (
+	progressService = "progress"
+	promptService   = "prompt"
+)
+
+/*
+ * The parts of the plugin broker used by the host services, so that they can also be provided to remotes served
+ * in-process.
+ */
+type hostBroker interface {
+	NextId() uint32
+	AcceptAndServe(id uint32, s func([]grpc.ServerOption) *grpc.Server)
+	Dial(id uint32) (*grpc.ClientConn, error)
+}
+
+/*
+ * Host side of the services, started lazily on first use and shared by all calls through the same client.
+ */
+type hostServices struct {
+	broker hostBroker
+
+	mu       sync.Mutex
+	id       uint32
+	nextCall uint64
+	calls    map[string]context.Context
+}
+
+func newHostServices(broker hostBroker) *hostServices {
+	return &hostServices{broker: broker, calls: map[string]context.Context{}}
+}
+
+/*
+ * Attach the host services to an outgoing call if the context requires them. The returned function must be invoked
+ * when the call completes.
+ */
+func (h *hostServices) attach(ctx context.Context) (context.Context, func()) {
+	if h == nil || h.broker == nil || (ProgressFromContext(ctx) == nil && PrompterFromContext(ctx) == nil) {
+		return ctx, func() {}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.id == 0 {
+		h.id = h.broker.NextId()
+		go h.broker.AcceptAndServe(h.id, func(opts []grpc.ServerOption) *grpc.Server {
+			s := grpc.NewServer(opts...)
+			proto.RegisterHostServer(s, &hostRPCServer{host: h})
+			return s
+		})
+	}
+	h.nextCall++
+	callId := strconv.FormatUint(h.nextCall, 10)
+	h.calls[callId] = ctx
+
+	ctx = metadata.AppendToOutgoingContext(ctx, hostIdKey, strconv.FormatUint(uint64(h.id), 10), callIdKey, callId)
+	if ProgressFromContext(ctx) != nil {
+		ctx = metadata.AppendToOutgoingContext(ctx, hostServicesKey, progressService)
+	}
+	if PrompterFromContext(ctx) != nil {
+		ctx = metadata.AppendToOutgoingContext(ctx, hostServicesKey, promptService)
+	}
+	return ctx, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.calls, callId)
+	}
+}
+
+func (h *hostServices) call(callId string) context.Context {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.calls[callId]
+}
+
+type hostRPCServer struct {
+	host *hostServices
+}
+
+func (s *hostRPCServer) ReportProgress(ctx context.Context, req *proto.ReportProgressRequest) (*proto.ReportProgressResponse, error) {
+	if callCtx := s.host.call(req.CallId); callCtx != nil {
+		ReportProgress(callCtx, Progress{
+			Phase:      req.Phase,
+			Message:    req.Message,
+			BytesDone:  req.BytesDone,
+			BytesTotal: req.BytesTotal,
+		})
+	}
+	return &proto.ReportProgressResponse{}, nil
+}
+
+func (s *hostRPCServer) Prompt(ctx context.Context, req *proto.PromptRequest) (*proto.PromptResponse, error) {
+	var prompter Prompter
+	callCtx := s.host.call(req.CallId)
+	if callCtx != nil {
+		prompter = PrompterFromContext(callCtx)
+	}
+	if prompter == nil {
+		return nil, encodeError(Errorf(ErrUnsupported, "no prompter is available"))
+	}
+
+	res := proto.PromptResponse{}
+	var err error
+	switch req.Kind {
+	case proto.PromptRequest_TEXT:
+		res.Text, err = prompter.Text(callCtx, req.Message, req.DefaultText)
+	case proto.PromptRequest_SECRET:
+		res.Text, err = prompter.Secret(callCtx, req.Message)
+	case proto.PromptRequest_CONFIRM:
+		res.Confirmed, err = prompter.Confirm(callCtx, req.Message, req.DefaultConfirm)
+	case proto.PromptRequest_CHOICE:
+		res.Text, err = prompter.Choice(callCtx, req.Message, req.Choices, req.DefaultText)
+	default:
+		err = Errorf(ErrUnsupported, "unknown prompt kind %v", req.Kind)
+	}
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return &res, nil
+}
+
+/*
+ * Plugin side of the services, dialing the host on demand. Each broker ID can only be dialed once, so connections are
+ * cached for the lifetime of the plugin.
+ */
+type hostConnections struct {
+	broker hostBroker
+
+	mu    sync.Mutex
+	conns map[uint32]*grpc.ClientConn
+}
+
+func newHostConnections(broker hostBroker) *hostConnections {
+	return &hostConnections{broker: broker, conns: map[uint32]*grpc.ClientConn{}}
+}
+
+func (h *hostConnections) dial(id uint32) (*grpc.ClientConn, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if conn, ok := h.conns[id]; ok {
+		return conn, nil
+	}
+	conn, err := h.broker.Dial(id)
+	if err != nil {
+		return nil, err
+	}
+	h.conns[id] = conn
+	return conn, nil
+}
+
+/*
+ * Returns a context for an incoming call with the host services attached by the caller, and only those, so that
+ * remotes can tell a non-interactive call by the lack of a prompter.
+ */
+func (h *hostConnections) context(ctx context.Context) context.Context {
+	if h == nil || h.broker == nil {
+		return ctx
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(hostIdKey)) == 0 || len(md.Get(callIdKey)) == 0 {
+		return ctx
+	}
+	id, err := strconv.ParseUint(md.Get(hostIdKey)[0], 10, 32)
+	if err != nil {
+		return ctx
+	}
+	conn, err := h.dial(uint32(id))
+	if err != nil {
+		return ctx
+	}
+	client := proto.NewHostClient(conn)
+	callId := md.Get(callIdKey)[0]
+	services := md.Get(hostServicesKey)
+	if contains(services, progressService) {
+		ctx = WithProgress(ctx, &hostProgressReporter{ctx: ctx, client: client, callId: callId})
+	}
+	if contains(services, promptService) {
+		ctx = WithPrompter(ctx, &hostPrompter{client: client, callId: callId})
+	}
+	return ctx
+}
+
+/*
+ * Forwards progress to the host. Progress is best-effort, so any failure to deliver it is ignored.
+ */
+type hostProgressReporter struct {
+	ctx    context.Context
+	client proto.HostClient
+	callId string
+}
+
+func (r *hostProgressReporter) Report(progress Progress) {
+	_, _ = r.client.ReportProgress(r.ctx, &proto.ReportProgressRequest{
+		CallId:     r.callId,
+		Phase:      progress.Phase,
+		Message:    progress.Message,
+		BytesDone:  progress.BytesDone,
+		BytesTotal: progress.BytesTotal,
+	})
+}
+
+/*
+ * Forwards prompts to the host. Fails with ErrUnsupported if the host has no prompter attached to the call.
+ */
+type hostPrompter struct {
+	client proto.HostClient
+	callId string
+}
+
+func (p *hostPrompter) prompt(ctx context.Context, req *proto.PromptRequest) (*proto.PromptResponse, error) {
+	req.CallId = p.callId
+	res, err := p.client.Prompt(ctx, req)
+	if err != nil {
+		return nil, decodeError(err)
+	}
+	return res, nil
+}
+
+func (p *hostPrompter) Text(ctx context.Context, message string, defaultValue string) (string, error) {
+	res, err := p.prompt(ctx, &proto.PromptRequest{
+		Kind:        proto.PromptRequest_TEXT,
+		Message:     message,
+		DefaultText: defaultValue,
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.Text, nil
+}
+
+func (p *hostPrompter) Secret(ctx context.Context, message string) (string, error) {
+	res, err := p.prompt(ctx, &proto.PromptRequest{Kind: proto.PromptRequest_SECRET, Message: message})
+	if err != nil {
+		return "", err
+	}
+	return res.Text, nil
+}
+
+func (p *hostPrompter) Confirm(ctx context.Context, message string, defaultValue bool) (bool, error) {
+	res, err := p.prompt(ctx, &proto.PromptRequest{
+		Kind:           proto.PromptRequest_CONFIRM,
+		Message:        message,
+		DefaultConfirm: defaultValue,
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.Confirmed, nil
+}
+
+func (p *hostPrompter) Choice(ctx context.Context, message string, choices []string, defaultValue string) (string, error) {
+	res, err := p.prompt(ctx, &proto.PromptRequest{
+		Kind:        proto.PromptRequest_CHOICE,
+		Message:     message,
+		Choices:     choices,
+		DefaultText: defaultValue,
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.Text, nil
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	proto "github.com/titan-data/remote-sdk-go/internal/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+/*
+ * Size of the in-memory buffer backing each in-process connection.
+ */
+const inProcessBufferSize = 1024 * 1024
+
+/*
+ * Serve a registered remote in-process, returning a remote that calls it over gRPC as if it had been loaded as a
+ * plugin. Every call goes through the same serialization, error encoding, redaction, and host services as a real
+ * plugin, but no binary needs to be built or launched, which makes it suitable for testing remotes with a plain
+ * "go test". The remote is served until Unload() or Clear() is called, and until then Load() returns it rather than
+ * launching a plugin, so that code which loads remotes can be tested against registered fakes. Calling this again
+ * returns the remote already being served. Fails with ErrNotFound if no remote of the type has been registered.
+ */
+func (r *Registry) LoadInProcess(remoteType string) (Remote, error) {
+	lock := r.loadLock(remoteType)
+	lock.Lock()
+	defer lock.Unlock()
+	if p := r.getInProcess(remoteType); p != nil {
+		return WithoutContext(p.client), nil
+	}
+
+	impl := r.getRegistered(remoteType)
+	if impl == nil {
+		return nil, Errorf(ErrNotFound, "no remote '%s' is registered", remoteType)
+	}
+	p, err := serveInProcess(remoteType, impl)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inProcess[remoteType] = p
+	return WithoutContext(p.client), nil
+}
+
+/*
+ * Serve a remote registered with the default registry in-process. See Registry.LoadInProcess().
+ */
+func LoadInProcess(remoteType string) (Remote, error) {
+	return defaultRegistry.LoadInProcess(remoteType)
+}
+
+/*
+ * A remote served in-process over an in-memory connection.
+ */
+type inProcessPlugin struct {
+	remoteType string
+	server     *grpc.Server
+	conn       *grpc.ClientConn
+	broker     *memoryBroker
+	client     *remoteRPCClient
+}
+
+func serveInProcess(remoteType string, impl Remote) (*inProcessPlugin, error) {
+	broker := newMemoryBroker()
+	listener := bufconn.Listen(inProcessBufferSize)
+	server := newGRPCServer(nil)
+	proto.RegisterRemoteServer(server, newRemoteRPCServer(impl, broker, nil))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	conn, err := dialBufconn(listener)
+	if err != nil {
+		server.Stop()
+		return nil, err
+	}
+
+	// As with plugins, the host has its own redactor, which learns the sensitive properties from the remote's schema
+	client := newRemoteRPCClient(conn, broker, ProtocolVersion, NewRedactor())
+	if schema, err := GetSchema(context.Background(), WithoutContext(client)); err == nil {
+		client.redactor.AddSensitive(SensitiveProperties(schema)...)
+	}
+	return &inProcessPlugin{remoteType: remoteType, server: server, conn: conn, broker: broker, client: client}, nil
+}
+
+/*
+ * In-process remotes have no process to supervise, so they are always running and never restarted.
+ */
+func (p *inProcessPlugin) status() PluginStatus {
+	return PluginStatus{Type: p.remoteType, Version: p.client.version, Running: true}
+}
+
+func (p *inProcessPlugin) close() {
+	_ = p.conn.Close()
+	p.server.Stop()
+	p.broker.close()
+}
+
+func dialBufconn(listener *bufconn.Listener) (*grpc.ClientConn, error) {
+	return grpc.Dial("bufconn",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.Dial()
+		}))
+}
+
+/*
+ * An in-memory stand-in for the plugin broker, serving the host services over bufconn listeners.
+ */
+type memoryBroker struct {
+	nextId uint32
+
+	mu        sync.Mutex
+	listeners map[uint32]*bufconn.Listener
+	servers   []*grpc.Server
+	conns     []*grpc.ClientConn
+	closed    bool
+}
+
+func newMemoryBroker() *memoryBroker {
+	return &memoryBroker{listeners: map[uint32]*bufconn.Listener{}}
+}
+
+func (b *memoryBroker) NextId() uint32 {
+	return atomic.AddUint32(&b.nextId, 1)
+}
+
+/*
+ * Returns the listener for the given ID, creating it if needed. Must be called with the lock held.
+ */
+func (b *memoryBroker) listener(id uint32) *bufconn.Listener {
+	l, ok := b.listeners[id]
+	if !ok {
+		l = bufconn.Listen(inProcessBufferSize)
+		b.listeners[id] = l
+	}
+	return l
+}
+
+/*
+ * Serve on the listener for the given ID until the broker is closed. Nothing is served once the broker is closed.
+ */
+func (b *memoryBroker) AcceptAndServe(id uint32, newServer func([]grpc.ServerOption) *grpc.Server) {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	listener := b.listener(id)
+	s := newServer(nil)
+	b.servers = append(b.servers, s)
+	b.mu.Unlock()
+	_ = s.Serve(listener)
+}
+
+func (b *memoryBroker) Dial(id uint32) (*grpc.ClientConn, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, Errorf(ErrUnavailable, "in-process remote has been unloaded")
+	}
+	// Dialing doesn't block, so the connection can be made while holding the lock
+	conn, err := dialBufconn(b.listener(id))
+	if err != nil {
+		return nil, err
+	}
+	b.conns = append(b.conns, conn)
+	return conn, nil
+}
+
+func (b *memoryBroker) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for _, c := range b.conns {
+		_ = c.Close()
+	}
+	for _, s := range b.servers {
+		s.Stop()
+	}
+	for _, l := range b.listeners {
+		_ = l.Close()
+	}
+}
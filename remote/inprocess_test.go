@@ -0,0 +1,200 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"testing"
+	"time"
+)
+
+func TestLoadInProcess(t *testing.T) {
+	reg := NewRegistry()
+	defer reg.Clear()
+	m := mockRemote("mock")
+	m.On("FromURL", "mock://host", map[string]string{"a": "b"}).Return(map[string]interface{}{
+		"host":   "host",
+		"port":   22,
+		"nested": map[string]interface{}{"list": []interface{}{"x", true}},
+	}, nil)
+	reg.Register(m)
+
+	r, err := reg.LoadInProcess("mock")
+	if assert.NoError(t, err) {
+		typ, err := r.Type()
+		if assert.NoError(t, err) {
+			assert.Equal(t, "mock", typ)
+		}
+		props, err := r.FromURL("mock://host", map[string]string{"a": "b"})
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]interface{}{
+				"host":   "host",
+				"port":   22.0,
+				"nested": map[string]interface{}{"list": []interface{}{"x", true}},
+			}, props)
+		}
+	}
+	m.AssertExpectations(t)
+}
+
+func TestLoadInProcessNotFound(t *testing.T) {
+	reg := NewRegistry()
+	_, err := reg.LoadInProcess("mock")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestLoadInProcessErrors(t *testing.T) {
+	reg := NewRegistry()
+	defer reg.Clear()
+	m := mockRemote("mock")
+	v := &ValidationError{}
+	v.Add("/host", "missing required property")
+	m.On("ValidateRemote", map[string]interface{}{}).Return(v)
+	reg.Register(m)
+
+	r, err := reg.LoadInProcess("mock")
+	if assert.NoError(t, err) {
+		err = r.ValidateRemote(map[string]interface{}{})
+		if assert.Error(t, err) {
+			assert.True(t, errors.Is(err, ErrInvalidProperty))
+			assert.Equal(t, "/host: missing required property", err.Error())
+		}
+	}
+}
+
+func TestLoadInProcessPrompt(t *testing.T) {
+	reg := NewRegistry()
+	defer reg.Clear()
+	reg.RegisterWithContext(promptingRemote{WithContext(mockRemote("prompt"))})
+
+	r, err := reg.LoadInProcess("prompt")
+	if assert.NoError(t, err) {
+		p := NewScriptedPrompter("admin", "secret", "y", "")
+		params, err := WithContext(r).GetParameters(WithPrompter(context.Background(), p), map[string]interface{}{})
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]interface{}{
+				"user":     "admin",
+				"password": "secret",
+				"save":     true,
+				"region":   "us-east-1",
+			}, params)
+		}
+	}
+}
+
+func TestUnloadInProcess(t *testing.T) {
+	reg := NewRegistry()
+	defer reg.Clear()
+	reg.Register(mockRemote("mock"))
+
+	r, err := reg.LoadInProcess("mock")
+	if assert.NoError(t, err) {
+		reg.Unload("mock")
+		_, err = r.Type()
+		assert.Error(t, err)
+	}
+}
+
+func TestLoadAfterInProcess(t *testing.T) {
+	reg := NewRegistry()
+	defer reg.Clear()
+	m := mockRemote("mock")
+	m.On("ValidateRemote", map[string]interface{}{"a": "b"}).Return(nil)
+	reg.Register(m)
+
+	_, err := reg.LoadInProcess("mock")
+	if assert.NoError(t, err) {
+		r, err := reg.Load("mock", "/nonexistent")
+		if assert.NoError(t, err) {
+			assert.NoError(t, r.ValidateRemote(map[string]interface{}{"a": "b"}))
+			assert.IsType(t, &remoteRPCClient{}, underlying(r))
+		}
+	}
+	m.AssertExpectations(t)
+}
+
+func TestLoadDuringInProcess(t *testing.T) {
+	reg := NewRegistry()
+	defer reg.Clear()
+	m := mockRemote("mock")
+	reg.Register(m)
+
+	lock := reg.loadLock("mock")
+	lock.Lock()
+	done := make(chan Remote)
+	go func() {
+		r, err := reg.Load("mock", "/nonexistent")
+		assert.NoError(t, err)
+		done <- r
+	}()
+	time.Sleep(10 * time.Millisecond)
+	p, err := serveInProcess("mock", m)
+	if !assert.NoError(t, err) {
+		lock.Unlock()
+		return
+	}
+	reg.mu.Lock()
+	reg.inProcess["mock"] = p
+	reg.mu.Unlock()
+	lock.Unlock()
+
+	r := <-done
+	assert.IsType(t, &remoteRPCClient{}, underlying(r))
+	assert.Nil(t, reg.getLoaded("mock"))
+
+	reg.Unload("mock")
+	assert.Empty(t, reg.loading)
+}
+
+func TestLoadInProcessTwice(t *testing.T) {
+	reg := NewRegistry()
+	defer reg.Clear()
+	reg.Register(mockRemote("mock"))
+
+	first, err := reg.LoadInProcess("mock")
+	if !assert.NoError(t, err) {
+		return
+	}
+	second, err := reg.LoadInProcess("mock")
+	if assert.NoError(t, err) {
+		assert.Equal(t, first, second)
+		typ, err := first.Type()
+		if assert.NoError(t, err) {
+			assert.Equal(t, "mock", typ)
+		}
+	}
+}
+
+func TestInProcessStatus(t *testing.T) {
+	reg := NewRegistry()
+	defer reg.Clear()
+	reg.Register(mockRemote("mock"))
+	_, ok := reg.Status("mock")
+	assert.False(t, ok)
+	assert.Equal(t, 0, reg.NegotiatedVersion("mock"))
+
+	_, err := reg.LoadInProcess("mock")
+	if assert.NoError(t, err) {
+		status, ok := reg.Status("mock")
+		if assert.True(t, ok) {
+			assert.Equal(t, PluginStatus{Type: "mock", Version: ProtocolVersion, Running: true}, status)
+		}
+		assert.Equal(t, ProtocolVersion, reg.NegotiatedVersion("mock"))
+	}
+}
+
+func TestMemoryBrokerClosed(t *testing.T) {
+	b := newMemoryBroker()
+	b.close()
+	b.AcceptAndServe(b.NextId(), func(opts []grpc.ServerOption) *grpc.Server {
+		t.Error("server created after the broker was closed")
+		return grpc.NewServer(opts...)
+	})
+	_, err := b.Dial(1)
+	assert.True(t, errors.Is(err, ErrUnavailable))
+	assert.Empty(t, b.listeners)
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+)
+
+/*
+ * Iterates over a sequence of commits without holding the full list in memory. Callers should loop while Next()
+ * returns true, reading each commit with Commit(), and then check Err(). Close() must be called when the caller is
+ * done with the iterator, even if it has not been exhausted.
+ */
+type CommitIterator interface {
+	Next() bool
+	Commit() Commit
+	Err() error
+	Close() error
+}
+
+/*
+ * Optional interface for remotes that can produce commits incrementally. The iterator must yield commits in the same
+ * order and with the same tag filtering as ListCommits().
+ */
+type IterableRemote interface {
+	IterateCommits(ctx context.Context, properties map[string]interface{}, parameters map[string]interface{}, tags []Tag) (CommitIterator, error)
+}
+
+/*
+ * Number of commits fetched per page when iterating over a remote that supports pagination but not iteration.
+ */
+const iteratorPageSize = 100
+
+/*
+ * Iterate over the commits of the given remote. Remotes that implement IterableRemote are used directly, remotes that
+ * implement PaginatedRemote are fetched one page at a time, and all other remotes fall back to ListCommits().
+ */
+func IterateCommits(ctx context.Context, r Remote, properties map[string]interface{}, parameters map[string]interface{}, tags []Tag) (CommitIterator, error) {
+	return iterateCommits(ctx, WithContext(r), properties, parameters, tags)
+}
+
+func iterateCommits(ctx context.Context, r RemoteWithContext, properties map[string]interface{}, parameters map[string]interface{}, tags []Tag) (CommitIterator, error) {
+	switch impl := underlying(r).(type) {
+	case IterableRemote:
+		return impl.IterateCommits(ctx, properties, parameters, tags)
+	case PaginatedRemote:
+		return &pageIterator{
+			ctx:        ctx,
+			impl:       impl,
+			properties: properties,
+			parameters: parameters,
+			tags:       tags,
+		}, nil
+	default:
+		commits, err := r.ListCommits(ctx, properties, parameters, tags)
+		if err != nil {
+			return nil, err
+		}
+		return &sliceIterator{commits: commits, pos: -1}, nil
+	}
+}
+
+type sliceIterator struct {
+	commits []Commit
+	pos     int
+}
+
+func (i *sliceIterator) Next() bool {
+	if i.pos < len(i.commits) {
+		i.pos++
+	}
+	return i.pos < len(i.commits)
+}
+
+func (i *sliceIterator) Commit() Commit {
+	return i.commits[i.pos]
+}
+
+func (i *sliceIterator) Err() error {
+	return nil
+}
+
+func (i *sliceIterator) Close() error {
+	i.commits = nil
+	i.pos = 0
+	return nil
+}
+
+type pageIterator struct {
+	ctx        context.Context
+	impl       PaginatedRemote
+	properties map[string]interface{}
+	parameters map[string]interface{}
+	tags       []Tag
+
+	page    sliceIterator
+	token   string
+	started bool
+	err     error
+}
+
+func (i *pageIterator) Next() bool {
+	for !i.page.Next() {
+		if i.err != nil || (i.started && i.token == "") {
+			return false
+		}
+		page, err := i.impl.ListCommitsPage(i.ctx, i.properties, i.parameters, i.tags,
+			PageRequest{Size: iteratorPageSize, Token: i.token})
+		if err != nil {
+			i.err = err
+			return false
+		}
+		i.started = true
+		i.token = page.NextToken
+		i.page = sliceIterator{commits: page.Commits, pos: -1}
+	}
+	return true
+}
+
+func (i *pageIterator) Commit() Commit {
+	return i.page.Commit()
+}
+
+func (i *pageIterator) Err() error {
+	return i.err
+}
+
+func (i *pageIterator) Close() error {
+	i.started = true
+	i.token = ""
+	return i.page.Close()
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func collectCommits(t *testing.T, iter CommitIterator) []string {
+	ids := []string{}
+	for iter.Next() {
+		ids = append(ids, iter.Commit().Id)
+	}
+	assert.NoError(t, iter.Err())
+	assert.NoError(t, iter.Close())
+	return ids
+}
+
+func TestIterateCommits(t *testing.T) {
+	r := pagedRemote()
+	iter, err := IterateCommits(context.Background(), r, map[string]interface{}{}, map[string]interface{}{}, []Tag{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"three", "two", "one"}, collectCommits(t, iter))
+	}
+}
+
+type countingPagedRemote struct {
+	*MockRemote
+	total int
+	calls int
+	err   error
+}
+
+func (r *countingPagedRemote) ListCommitsPage(ctx context.Context, properties map[string]interface{}, parameters map[string]interface{}, tags []Tag, page PageRequest) (CommitPage, error) {
+	r.calls++
+	if r.err != nil {
+		return CommitPage{}, r.err
+	}
+	offset, _ := decodePageToken(page.Token)
+	ret := CommitPage{}
+	for i := offset; i < r.total && i < offset+page.Size; i++ {
+		ret.Commits = append(ret.Commits, Commit{Id: string(rune('a' + i%26))})
+	}
+	if offset+page.Size < r.total {
+		ret.NextToken = encodePageToken(offset + page.Size)
+	}
+	return ret, nil
+}
+
+func TestIterateCommitsPaged(t *testing.T) {
+	r := &countingPagedRemote{MockRemote: new(MockRemote), total: iteratorPageSize + 1}
+	iter, err := IterateCommits(context.Background(), r, map[string]interface{}{}, map[string]interface{}{}, []Tag{})
+	if assert.NoError(t, err) {
+		assert.Len(t, collectCommits(t, iter), iteratorPageSize+1)
+		assert.Equal(t, 2, r.calls)
+	}
+}
+
+func TestIterateCommitsPagedError(t *testing.T) {
+	r := &countingPagedRemote{MockRemote: new(MockRemote), err: errors.New("error")}
+	iter, err := IterateCommits(context.Background(), r, map[string]interface{}{}, map[string]interface{}{}, []Tag{})
+	if assert.NoError(t, err) {
+		assert.False(t, iter.Next())
+		assert.Error(t, iter.Err())
+	}
+}
+
+func TestIterateCommitsClose(t *testing.T) {
+	r := pagedRemote()
+	iter, err := IterateCommits(context.Background(), r, map[string]interface{}{}, map[string]interface{}{}, []Tag{})
+	if assert.NoError(t, err) {
+		assert.True(t, iter.Next())
+		assert.NoError(t, iter.Close())
+		assert.False(t, iter.Next())
+	}
+}
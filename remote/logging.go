@@ -0,0 +1,172 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"github.com/hashicorp/go-hclog"
+	"io"
+	"log"
+)
+
+/*
+ * Logging configuration, shared by registries and Serve().
+ */
+type logOptions struct {
+	logger    hclog.Logger
+	logLevel  hclog.Level
+	logOutput io.Writer
+	jsonLogs  bool
+}
+
+/*
+ * Configures logging. Logging options can be passed both to NewRegistry() and to Serve(), which accepts no other
+ * options.
+ */
+type LogOption func(*logOptions)
+
+func (f LogOption) apply(o *options) {
+	f(&o.logOptions)
+}
+
+func newLogOptions(opts []LogOption) logOptions {
+	o := logOptions{logLevel: hclog.Error}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+/*
+ * Log through the given logger, rather than one created by the SDK. The level, output, and format options are ignored
+ * when a logger is provided.
+ */
+func WithLogger(logger hclog.Logger) LogOption {
+	return func(o *logOptions) {
+		o.logger = logger
+	}
+}
+
+/*
+ * Log messages at or above the given level, rather than only errors.
+ */
+func WithLogLevel(level hclog.Level) LogOption {
+	return func(o *logOptions) {
+		o.logLevel = level
+	}
+}
+
+/*
+ * Write logs to the given writer. By default, hosts log to stdout while plugins log to stderr, where they are read by
+ * the host.
+ */
+func WithLogOutput(w io.Writer) LogOption {
+	return func(o *logOptions) {
+		o.logOutput = w
+	}
+}
+
+/*
+ * Write logs as JSON rather than text. Plugins always log as JSON unless another output is given, so that the host
+ * can preserve the level and fields of each message.
+ */
+func WithJSONLogs() LogOption {
+	return func(o *logOptions) {
+		o.jsonLogs = true
+	}
+}
+
+/*
+ * Create the logger described by the options, falling back to the given output and format. Everything logged is
+ * passed through the redactor.
+ */
+func (o *logOptions) newLogger(output io.Writer, json bool, redactor *Redactor) hclog.Logger {
+	logger := o.logger
+	if logger == nil {
+		if o.logOutput != nil {
+			output = o.logOutput
+			json = false
+		}
+		logger = hclog.New(&hclog.LoggerOptions{
+			Name:       "remote",
+			Output:     output,
+			Level:      o.logLevel,
+			JSONFormat: json || o.jsonLogs,
+		})
+	}
+	return &redactingLogger{Logger: logger, r: redactor}
+}
+
+/*
+ * A logger that redacts secrets from messages and string arguments.
+ */
+type redactingLogger struct {
+	hclog.Logger
+	r *Redactor
+}
+
+func (l *redactingLogger) redact(msg string, args []interface{}) (string, []interface{}) {
+	ret := make([]interface{}, len(args))
+	for i, a := range args {
+		switch v := a.(type) {
+		case string:
+			ret[i] = l.r.Redact(v)
+		case error:
+			ret[i] = l.r.RedactError(v)
+		default:
+			ret[i] = a
+		}
+	}
+	return l.r.Redact(msg), ret
+}
+
+func (l *redactingLogger) Log(level hclog.Level, msg string, args ...interface{}) {
+	msg, args = l.redact(msg, args)
+	l.Logger.Log(level, msg, args...)
+}
+
+func (l *redactingLogger) Trace(msg string, args ...interface{}) {
+	msg, args = l.redact(msg, args)
+	l.Logger.Trace(msg, args...)
+}
+
+func (l *redactingLogger) Debug(msg string, args ...interface{}) {
+	msg, args = l.redact(msg, args)
+	l.Logger.Debug(msg, args...)
+}
+
+func (l *redactingLogger) Info(msg string, args ...interface{}) {
+	msg, args = l.redact(msg, args)
+	l.Logger.Info(msg, args...)
+}
+
+func (l *redactingLogger) Warn(msg string, args ...interface{}) {
+	msg, args = l.redact(msg, args)
+	l.Logger.Warn(msg, args...)
+}
+
+func (l *redactingLogger) Error(msg string, args ...interface{}) {
+	msg, args = l.redact(msg, args)
+	l.Logger.Error(msg, args...)
+}
+
+func (l *redactingLogger) With(args ...interface{}) hclog.Logger {
+	_, args = l.redact("", args)
+	return &redactingLogger{Logger: l.Logger.With(args...), r: l.r}
+}
+
+func (l *redactingLogger) Named(name string) hclog.Logger {
+	return &redactingLogger{Logger: l.Logger.Named(name), r: l.r}
+}
+
+func (l *redactingLogger) ResetNamed(name string) hclog.Logger {
+	return &redactingLogger{Logger: l.Logger.ResetNamed(name), r: l.r}
+}
+
+func (l *redactingLogger) StandardLogger(opts *hclog.StandardLoggerOptions) *log.Logger {
+	return log.New(l.StandardWriter(opts), "", 0)
+}
+
+func (l *redactingLogger) StandardWriter(opts *hclog.StandardLoggerOptions) io.Writer {
+	return l.r.Writer(l.Logger.StandardWriter(opts))
+}
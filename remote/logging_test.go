@@ -0,0 +1,132 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+/*
+ * A buffer that can be written by the plugin's stderr reader while being read by the test.
+ */
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestLoggerDefaults(t *testing.T) {
+	var out bytes.Buffer
+	o := newOptions(nil)
+	logger := o.newLogger(&out, false, nil)
+	logger.Info("hidden")
+	logger.Error("shown")
+	assert.NotContains(t, out.String(), "hidden")
+	assert.Contains(t, out.String(), "[ERROR] remote: shown")
+}
+
+func TestLoggerOptions(t *testing.T) {
+	var def, out bytes.Buffer
+	o := newOptions([]Option{WithLogLevel(hclog.Debug), WithLogOutput(&out), WithJSONLogs()})
+	logger := o.newLogger(&def, false, nil)
+	logger.Debug("message", "key", "value")
+	assert.Empty(t, def.String())
+
+	var entry map[string]interface{}
+	if assert.NoError(t, json.Unmarshal(out.Bytes(), &entry)) {
+		assert.Equal(t, "message", entry["@message"])
+		assert.Equal(t, "debug", entry["@level"])
+		assert.Equal(t, "value", entry["key"])
+	}
+}
+
+func TestLoggerOutputOverridesDefaultFormat(t *testing.T) {
+	var out bytes.Buffer
+	o := newOptions([]Option{WithLogOutput(&out)})
+	o.newLogger(nil, true, nil).Error("message")
+	assert.Contains(t, out.String(), "[ERROR] remote: message")
+}
+
+func TestLoggerInjected(t *testing.T) {
+	var out bytes.Buffer
+	injected := hclog.New(&hclog.LoggerOptions{Name: "host", Output: &out, Level: hclog.Info})
+	o := newOptions([]Option{WithLogger(injected), WithLogLevel(hclog.Error)})
+	o.newLogger(nil, false, nil).Info("message")
+	assert.Contains(t, out.String(), "[INFO]  host: message")
+}
+
+func TestLoggerRedacted(t *testing.T) {
+	var out bytes.Buffer
+	r := NewRedactor("password")
+	r.Observe(map[string]interface{}{"password": "secret"})
+	o := newOptions([]Option{WithLogOutput(&out)})
+	logger := o.newLogger(nil, false, r).Named("sub").With("password", "secret")
+	logger.Error("bad secret", "err", errors.New("secret rejected"), "count", 1)
+	logger.StandardLogger(&hclog.StandardLoggerOptions{ForceLevel: hclog.Error}).Print("secret")
+	assert.NotContains(t, out.String(), "secret")
+	assert.Contains(t, out.String(), "bad ****")
+	assert.Contains(t, out.String(), "count=1")
+}
+
+func TestConfigure(t *testing.T) {
+	reg := NewRegistry()
+	reg.Configure(WithSearchPath("/a"), WithLogLevel(hclog.Debug))
+	assert.Equal(t, []string{"/a"}, reg.SearchPath())
+	assert.Equal(t, hclog.Debug, reg.currentOptions().logLevel)
+}
+
+func TestServeLogOptions(t *testing.T) {
+	o := newLogOptions(nil)
+	assert.Equal(t, hclog.Error, o.logLevel)
+	o = newLogOptions([]LogOption{WithLogLevel(hclog.Debug), WithJSONLogs()})
+	assert.Equal(t, hclog.Debug, o.logLevel)
+	assert.True(t, o.jsonLogs)
+}
+
+func TestPluginLogForwarding(t *testing.T) {
+	var out syncBuffer
+	logger := hclog.New(&hclog.LoggerOptions{Name: "host", Output: &out, Level: hclog.Debug})
+	reg := NewRegistry(WithLogger(logger), WithHealthInterval(0))
+	defer reg.Clear()
+	r, err := reg.Load("echo", "../build")
+	if !assert.NoError(t, err) {
+		return
+	}
+	o, _ := Operations(r)
+	if !assert.NoError(t, o.FailOperation(context.Background(), Operation{Id: "op"}, "broken")) {
+		return
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, line := range strings.Split(out.String(), "\n") {
+			if strings.Contains(line, "operation op failed: broken") {
+				assert.Contains(t, line, "remote=echo")
+				return
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("plugin log not forwarded: %s", out.String())
+}
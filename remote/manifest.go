@@ -0,0 +1,158 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+/*
+ * Returned by Load() when a plugin's manifest is malformed or doesn't match the plugin being loaded.
+ */
+var ErrInvalidManifest = errors.New("invalid manifest")
+
+/*
+ * Metadata describing a plugin binary, stored as JSON alongside the binary in a file named "<binary>.json", so that
+ * plugins can be inspected without executing them. Schemes are the URL schemes handled by the remote, which default
+ * to the remote type; ParseURL() uses them to find the remote for URLs whose scheme is not a remote type. Protocols
+ * are the plugin protocol versions supported by the plugin. The checksum is of the form "sha256:<hex digest>".
+ */
+type Manifest struct {
+	Type        string   `json:"type"`
+	Version     string   `json:"version,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Schemes     []string `json:"schemes,omitempty"`
+	Protocols   []int    `json:"protocols,omitempty"`
+	Checksum    string   `json:"checksum,omitempty"`
+}
+
+/*
+ * Optional interface for remotes that describe themselves in their plugin manifest. Only the version, description,
+ * and schemes are used; the remaining fields are filled in by the SDK.
+ */
+type ManifestRemote interface {
+	Manifest() Manifest
+}
+
+/*
+ * Returns the path of the manifest for the given plugin binary.
+ */
+func ManifestPath(binary string) string {
+	return binary + ".json"
+}
+
+/*
+ * Read the manifest for the given plugin binary. Returns nil if the plugin has no manifest.
+ */
+func ReadManifest(binary string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(ManifestPath(binary))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, Errorf(ErrInvalidManifest, "invalid manifest for plugin '%s': %v", binary, err)
+	}
+	return &m, nil
+}
+
+/*
+ * Write the manifest for the given plugin binary.
+ */
+func WriteManifest(binary string, m Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(ManifestPath(binary), append(data, '\n'), 0644)
+}
+
+/*
+ * Check that the manifest describes a plugin for the given remote type, and that the plugin supports at least one
+ * protocol version in common with this SDK. A manifest without a type, such as one that only records a checksum, is
+ * assumed to be for the given type.
+ */
+func (m *Manifest) Validate(remoteType string) error {
+	if m.Type != "" && m.Type != remoteType {
+		return Errorf(ErrInvalidManifest, "manifest is for remote '%s', not '%s'", m.Type, remoteType)
+	}
+	if len(m.Protocols) == 0 {
+		return nil
+	}
+	for _, v := range m.Protocols {
+		if v >= 1 && v <= ProtocolVersion {
+			return nil
+		}
+	}
+	return Errorf(ErrInvalidManifest, "remote '%s' supports protocol versions %v, but this SDK supports versions 1 to %d",
+		remoteType, m.Protocols, ProtocolVersion)
+}
+
+/*
+ * Generate the manifest for a registered remote, describing the currently running binary. Fails with ErrNotFound if
+ * the remote has not been registered.
+ */
+func GenerateManifest(remoteType string) (Manifest, error) {
+	r, err := servedRemote(remoteType)
+	if err != nil {
+		return Manifest{}, err
+	}
+	return generateManifest(context.Background(), r)
+}
+
+func generateManifest(ctx context.Context, r Remote) (Manifest, error) {
+	var m Manifest
+	if d, ok := underlying(r).(ManifestRemote); ok {
+		m = d.Manifest()
+	}
+
+	remoteType, err := WithContext(r).Type(ctx)
+	if err != nil {
+		return Manifest{}, err
+	}
+	m.Type = remoteType
+	if len(m.Schemes) == 0 {
+		m.Schemes = []string{remoteType}
+	}
+	m.Protocols = nil
+	for v := 1; v <= ProtocolVersion; v++ {
+		m.Protocols = append(m.Protocols, v)
+	}
+
+	binary, err := os.Executable()
+	if err != nil {
+		return Manifest{}, err
+	}
+	if m.Checksum, err = PluginChecksum(binary); err != nil {
+		return Manifest{}, err
+	}
+	return m, nil
+}
+
+/*
+ * Write the manifest for a registered remote as JSON. Serve() invokes this when the plugin is run with the
+ * "--manifest" argument, so that a manifest can be generated as part of building a plugin:
+ *
+ *      titan-remote-s3 --manifest > titan-remote-s3.json
+ */
+func ServeManifest(remoteType string, w io.Writer) error {
+	m, err := GenerateManifest(remoteType)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadManifest(t *testing.T) {
+	dir := tempPluginDir(t, map[string]os.FileMode{"titan-remote-s3": 0755})
+	defer os.RemoveAll(dir)
+	binary := filepath.Join(dir, "titan-remote-s3")
+
+	m, err := ReadManifest(binary)
+	if assert.NoError(t, err) {
+		assert.Nil(t, m)
+	}
+
+	err = ioutil.WriteFile(ManifestPath(binary), []byte(`{"checksum": "sha256:abcd"}`), 0644)
+	if !assert.NoError(t, err) {
+		return
+	}
+	m, err = ReadManifest(binary)
+	if assert.NoError(t, err) && assert.NotNil(t, m) {
+		assert.Equal(t, "sha256:abcd", m.Checksum)
+	}
+}
+
+func TestReadManifestInvalid(t *testing.T) {
+	dir := tempPluginDir(t, map[string]os.FileMode{"titan-remote-s3": 0755, "titan-remote-s3.json": 0644})
+	defer os.RemoveAll(dir)
+	_, err := ReadManifest(filepath.Join(dir, "titan-remote-s3"))
+	assert.Error(t, err)
+}
+
+func TestWriteManifest(t *testing.T) {
+	dir := tempPluginDir(t, map[string]os.FileMode{"titan-remote-s3": 0755})
+	defer os.RemoveAll(dir)
+	binary := filepath.Join(dir, "titan-remote-s3")
+	m := Manifest{Type: "s3", Version: "1.2.3", Schemes: []string{"s3"}, Protocols: []int{1, 2}}
+	if !assert.NoError(t, WriteManifest(binary, m)) {
+		return
+	}
+	read, err := ReadManifest(binary)
+	if assert.NoError(t, err) {
+		assert.Equal(t, &m, read)
+	}
+}
+
+func TestValidateManifest(t *testing.T) {
+	assert.NoError(t, (&Manifest{Type: "s3"}).Validate("s3"))
+	assert.NoError(t, (&Manifest{Type: "s3", Protocols: []int{ProtocolVersion, ProtocolVersion + 1}}).Validate("s3"))
+
+	m := &Manifest{}
+	if assert.NoError(t, m.Validate("s3")) {
+		assert.Empty(t, m.Type)
+	}
+
+	err := (&Manifest{Type: "ssh"}).Validate("s3")
+	if assert.True(t, errors.Is(err, ErrInvalidManifest)) {
+		assert.Equal(t, "manifest is for remote 'ssh', not 's3'", err.Error())
+	}
+
+	err = (&Manifest{Type: "s3", Protocols: []int{ProtocolVersion + 1}}).Validate("s3")
+	assert.True(t, errors.Is(err, ErrInvalidManifest))
+}
+
+type manifestRemote struct {
+	*MockRemote
+}
+
+func (r manifestRemote) Manifest() Manifest {
+	return Manifest{Type: "ignored", Version: "1.0", Description: "mock", Protocols: []int{42}}
+}
+
+func TestGenerateManifest(t *testing.T) {
+	r := new(MockRemote)
+	r.On("Type").Return("mock")
+	m, err := generateManifest(context.Background(), manifestRemote{r})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "mock", m.Type)
+		assert.Equal(t, "1.0", m.Version)
+		assert.Equal(t, "mock", m.Description)
+		assert.Equal(t, []string{"mock"}, m.Schemes)
+		assert.Len(t, m.Protocols, ProtocolVersion)
+		assert.Contains(t, m.Checksum, "sha256:")
+	}
+}
+
+func TestGenerateManifestUnknown(t *testing.T) {
+	Clear()
+	dir := tempPluginDir(t, map[string]os.FileMode{"titan-remote-mock": 0755})
+	defer os.RemoveAll(dir)
+	Configure(WithSearchPath(dir))
+	defer Configure(WithSearchPath())
+	_, err := GenerateManifest("mock")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Empty(t, defaultRegistry.List())
+}
+
+func TestServeManifest(t *testing.T) {
+	out, err := exec.Command("../build/echo", "--manifest").Output()
+	if !assert.NoError(t, err) {
+		return
+	}
+	var m Manifest
+	if assert.NoError(t, json.Unmarshal(out, &m)) {
+		assert.Equal(t, "echo", m.Type)
+		assert.Equal(t, "1.0.0", m.Version)
+		assert.Equal(t, []string{"echo"}, m.Schemes)
+		sum, err := PluginChecksum("../build/echo")
+		if assert.NoError(t, err) {
+			assert.Equal(t, sum, m.Checksum)
+		}
+	}
+}
+
+func TestLoadManifest(t *testing.T) {
+	dir, binary := copyEcho(t)
+	defer os.RemoveAll(dir)
+	out, err := exec.Command(binary, "--manifest").Output()
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, ioutil.WriteFile(ManifestPath(binary), out, 0644)) {
+		return
+	}
+
+	reg := NewRegistry(WithSearchPath(dir))
+	defer reg.Clear()
+	plugins := reg.Discover()
+	if assert.Len(t, plugins, 1) && assert.NotNil(t, plugins[0].Manifest) {
+		assert.Equal(t, "1.0.0", plugins[0].Manifest.Version)
+	}
+	_, err = reg.Load("echo", dir)
+	assert.NoError(t, err)
+}
+
+func TestLoadManifestMismatch(t *testing.T) {
+	dir, binary := copyEcho(t)
+	defer os.RemoveAll(dir)
+	writeManifest(t, binary, Manifest{Type: "s3"})
+
+	_, err := NewRegistry().Load("echo", dir)
+	assert.True(t, errors.Is(err, ErrInvalidManifest))
+}
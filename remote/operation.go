@@ -0,0 +1,90 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+)
+
+type OperationType string
+
+const (
+	OperationPush OperationType = "push"
+	OperationPull OperationType = "pull"
+)
+
+/*
+ * A push or pull operation driven by the server. The remote and parameters are the same properties returned by
+ * FromURL() and GetParameters(). Data is any state returned by StartOperation(), and is passed back unmodified to
+ * every subsequent call for the same operation.
+ */
+type Operation struct {
+	Id         string
+	Type       OperationType
+	CommitId   string
+	Remote     map[string]interface{}
+	Parameters map[string]interface{}
+	Data       map[string]interface{}
+}
+
+/*
+ * A volume within a commit. The path is the local mountpoint of the volume data, while the scratch path is a
+ * temporary directory that the remote can use during the sync.
+ */
+type Volume struct {
+	Name        string
+	Properties  map[string]interface{}
+	Path        string
+	ScratchPath string
+}
+
+/*
+ * Optional interface for remotes that can push and pull data on behalf of the server. The server drives every
+ * operation through the following lifecycle:
+ *
+ *      StartOperation -> [PushMetadata] -> SyncVolume (for each volume) -> EndOperation
+ *
+ * If any step fails, FailOperation is invoked instead of EndOperation so that the remote can clean up any partial
+ * state. PushMetadata is only invoked for push operations.
+ */
+type OperationRemote interface {
+
+	/*
+	 * Start a new operation. Any returned data is attached to the operation for all subsequent calls, allowing
+	 * remotes to preserve state (such as a temporary directory or session token) across calls.
+	 */
+	StartOperation(ctx context.Context, operation Operation) (map[string]interface{}, error)
+
+	/*
+	 * Push the metadata for the given commit to the remote. If isUpdate is true, then the commit already exists and
+	 * only its properties are being updated.
+	 */
+	PushMetadata(ctx context.Context, operation Operation, commit Commit, isUpdate bool) error
+
+	/*
+	 * Synchronize the data for a single volume, either pushing from the local path to the remote or pulling from the
+	 * remote into the local path, depending on the operation type.
+	 */
+	SyncVolume(ctx context.Context, operation Operation, volume Volume) error
+
+	/*
+	 * Complete a successful operation.
+	 */
+	EndOperation(ctx context.Context, operation Operation) error
+
+	/*
+	 * Abort a failed operation, with a human readable reason for the failure.
+	 */
+	FailOperation(ctx context.Context, operation Operation, reason string) error
+}
+
+/*
+ * Returns the operation interface for the given remote, or false if the remote does not support operations. Remotes
+ * loaded via plugins always return true, though calls will fail with ErrUnsupported if the plugin itself doesn't
+ * support operations.
+ */
+func Operations(r Remote) (OperationRemote, bool) {
+	o, ok := underlying(r).(OperationRemote)
+	return o, ok
+}
@@ -0,0 +1,26 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	proto "github.com/titan-data/remote-sdk-go/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestOperationsUnsupported(t *testing.T) {
+	_, ok := Operations(new(MockRemote))
+	assert.False(t, ok)
+}
+
+func TestServerOperationsUnsupported(t *testing.T) {
+	s := &remoteRPCServer{Impl: WithContext(new(MockRemote))}
+	_, err := s.StartOperation(context.Background(), &proto.StartOperationRequest{Operation: &proto.Operation{Id: "op"}})
+	assert.True(t, errors.Is(err, ErrUnsupported))
+	assert.Equal(t, codes.Unimplemented, status.Code(encodeError(err)))
+}
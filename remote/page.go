@@ -0,0 +1,102 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+)
+
+/*
+ * Identifies a page of commits to fetch. A size of zero means all remaining commits. The token is empty for the
+ * first page, and otherwise must be a token previously returned as CommitPage.NextToken.
+ */
+type PageRequest struct {
+	Size  int
+	Token string
+}
+
+/*
+ * A single page of commits. NextToken is empty when there are no further commits.
+ */
+type CommitPage struct {
+	Commits   []Commit
+	NextToken string
+}
+
+/*
+ * Optional interface for remotes that can paginate commits server-side. Page tokens are opaque to the SDK, and can
+ * encode whatever continuation state the remote requires. Remotes that do not implement this interface are paged
+ * client-side by ListCommitsPage().
+ */
+type PaginatedRemote interface {
+	ListCommitsPage(ctx context.Context, properties map[string]interface{}, parameters map[string]interface{}, tags []Tag, page PageRequest) (CommitPage, error)
+}
+
+/*
+ * Fetch a single page of commits from the given remote. If the remote doesn't support pagination natively, the full
+ * list of commits is fetched and sliced, with the offset encoded in the returned token. Tokens that weren't returned
+ * by a previous page fail with ErrInvalidProperty.
+ */
+func ListCommitsPage(ctx context.Context, r Remote, properties map[string]interface{}, parameters map[string]interface{}, tags []Tag, page PageRequest) (CommitPage, error) {
+	return listCommitsPage(ctx, WithContext(r), properties, parameters, tags, page)
+}
+
+func listCommitsPage(ctx context.Context, r RemoteWithContext, properties map[string]interface{}, parameters map[string]interface{}, tags []Tag, page PageRequest) (CommitPage, error) {
+	if p, ok := underlying(r).(PaginatedRemote); ok {
+		return p.ListCommitsPage(ctx, properties, parameters, tags, page)
+	}
+
+	if _, err := decodePageToken(page.Token); err != nil {
+		return CommitPage{}, err
+	}
+	commits, err := r.ListCommits(ctx, properties, parameters, tags)
+	if err != nil {
+		return CommitPage{}, err
+	}
+	return slicePage(commits, page)
+}
+
+/*
+ * Returns the given page from a full list of commits.
+ */
+func slicePage(commits []Commit, page PageRequest) (CommitPage, error) {
+	offset, err := decodePageToken(page.Token)
+	if err != nil {
+		return CommitPage{}, err
+	}
+	if offset > len(commits) {
+		offset = len(commits)
+	}
+	end := len(commits)
+	if page.Size > 0 && offset+page.Size < end {
+		end = offset + page.Size
+	}
+
+	ret := CommitPage{Commits: commits[offset:end]}
+	if end < len(commits) {
+		ret.NextToken = encodePageToken(end)
+	}
+	return ret, nil
+}
+
+func encodePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, Errorf(ErrInvalidProperty, "invalid page token '%s'", token)
+	}
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, Errorf(ErrInvalidProperty, "invalid page token '%s'", token)
+	}
+	return offset, nil
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func pagedRemote() *MockRemote {
+	r := new(MockRemote)
+	r.On("ListCommits", mock.Anything, mock.Anything, mock.Anything).Return([]Commit{
+		{Id: "three"}, {Id: "two"}, {Id: "one"},
+	}, nil)
+	return r
+}
+
+func TestListCommitsPage(t *testing.T) {
+	r := pagedRemote()
+	page, err := ListCommitsPage(context.Background(), r, map[string]interface{}{}, map[string]interface{}{}, []Tag{}, PageRequest{Size: 2})
+	if assert.NoError(t, err) {
+		assert.Len(t, page.Commits, 2)
+		assert.Equal(t, "three", page.Commits[0].Id)
+		assert.Equal(t, "two", page.Commits[1].Id)
+		assert.NotEmpty(t, page.NextToken)
+	}
+
+	page, err = ListCommitsPage(context.Background(), r, map[string]interface{}{}, map[string]interface{}{}, []Tag{}, PageRequest{Size: 2, Token: page.NextToken})
+	if assert.NoError(t, err) {
+		assert.Len(t, page.Commits, 1)
+		assert.Equal(t, "one", page.Commits[0].Id)
+		assert.Empty(t, page.NextToken)
+	}
+}
+
+func TestListCommitsPageAll(t *testing.T) {
+	r := pagedRemote()
+	page, err := ListCommitsPage(context.Background(), r, map[string]interface{}{}, map[string]interface{}{}, []Tag{}, PageRequest{})
+	if assert.NoError(t, err) {
+		assert.Len(t, page.Commits, 3)
+		assert.Empty(t, page.NextToken)
+	}
+}
+
+func TestListCommitsPageBadToken(t *testing.T) {
+	r := pagedRemote()
+	_, err := ListCommitsPage(context.Background(), r, map[string]interface{}{}, map[string]interface{}{}, []Tag{}, PageRequest{Token: "!!"})
+	assert.True(t, errors.Is(err, ErrInvalidProperty))
+	r.AssertNotCalled(t, "ListCommits", mock.Anything, mock.Anything, mock.Anything)
+}
+
+type nativePagedRemote struct {
+	*MockRemote
+}
+
+func (r nativePagedRemote) ListCommitsPage(ctx context.Context, properties map[string]interface{}, parameters map[string]interface{}, tags []Tag, page PageRequest) (CommitPage, error) {
+	return CommitPage{Commits: []Commit{{Id: page.Token}}, NextToken: "next"}, nil
+}
+
+func TestListCommitsPageNative(t *testing.T) {
+	r := nativePagedRemote{new(MockRemote)}
+	page, err := ListCommitsPage(context.Background(), r, map[string]interface{}{}, map[string]interface{}{}, []Tag{}, PageRequest{Size: 1, Token: "token"})
+	if assert.NoError(t, err) {
+		assert.Len(t, page.Commits, 1)
+		assert.Equal(t, "token", page.Commits[0].Id)
+		assert.Equal(t, "next", page.NextToken)
+	}
+}
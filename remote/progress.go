@@ -0,0 +1,64 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+)
+
+/*
+ * A progress event emitted by a remote during a long-running operation. The phase is a short machine-friendly
+ * identifier (such as "upload"), while the message is human readable. The byte counts are optional, with a zero
+ * total indicating that the total size is unknown.
+ */
+type Progress struct {
+	Phase      string
+	Message    string
+	BytesDone  int64
+	BytesTotal int64
+}
+
+/*
+ * Receives progress events from a remote.
+ */
+type ProgressReporter interface {
+	Report(progress Progress)
+}
+
+/*
+ * Adapts a function to the ProgressReporter interface.
+ */
+type ProgressFunc func(progress Progress)
+
+func (f ProgressFunc) Report(progress Progress) {
+	f(progress)
+}
+
+type progressKey struct{}
+
+/*
+ * Returns a context that carries the given progress reporter. Remotes invoked with this context (directly or through
+ * the plugin interface) can report progress via ReportProgress(), which will be delivered to the reporter on the host.
+ */
+func WithProgress(ctx context.Context, reporter ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressKey{}, reporter)
+}
+
+/*
+ * Returns the progress reporter attached to the context, or nil if there is none.
+ */
+func ProgressFromContext(ctx context.Context) ProgressReporter {
+	reporter, _ := ctx.Value(progressKey{}).(ProgressReporter)
+	return reporter
+}
+
+/*
+ * Report progress to the reporter attached to the given context, if any. This is intended to be invoked by remote
+ * implementations within operations and transfers.
+ */
+func ReportProgress(ctx context.Context, progress Progress) {
+	if reporter := ProgressFromContext(ctx); reporter != nil {
+		reporter.Report(progress)
+	}
+}
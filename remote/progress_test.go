@@ -0,0 +1,31 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReportProgress(t *testing.T) {
+	events := []Progress{}
+	ctx := WithProgress(context.Background(), ProgressFunc(func(p Progress) {
+		events = append(events, p)
+	}))
+	ReportProgress(ctx, Progress{Phase: "upload", BytesDone: 1, BytesTotal: 2})
+	assert.Equal(t, []Progress{{Phase: "upload", BytesDone: 1, BytesTotal: 2}}, events)
+}
+
+func TestReportProgressNoReporter(t *testing.T) {
+	assert.Nil(t, ProgressFromContext(context.Background()))
+	ReportProgress(context.Background(), Progress{Phase: "upload"})
+}
+
+func TestHostServicesNotAttached(t *testing.T) {
+	var h *hostServices
+	ctx, done := h.attach(WithProgress(context.Background(), ProgressFunc(func(p Progress) {})))
+	defer done()
+	assert.NotNil(t, ProgressFromContext(ctx))
+}
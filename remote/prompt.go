@@ -0,0 +1,148 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+/*
+ * Interactively prompts the user for input. Hosts attach a prompter to the context passed to GetParameters(), so that
+ * remotes can ask for information that can't be found in the user's configuration, such as a password. Remotes loaded
+ * as plugins have no terminal of their own, so their prompts are forwarded to the prompter on the host.
+ */
+type Prompter interface {
+
+	/*
+	 * Prompt for a line of text, returning the default value if the user enters nothing.
+	 */
+	Text(ctx context.Context, message string, defaultValue string) (string, error)
+
+	/*
+	 * Prompt for a secret, such as a password, without echoing the input.
+	 */
+	Secret(ctx context.Context, message string) (string, error)
+
+	/*
+	 * Prompt for a yes or no answer, returning the default value if the user enters nothing.
+	 */
+	Confirm(ctx context.Context, message string, defaultValue bool) (bool, error)
+
+	/*
+	 * Prompt the user to pick one of the given choices, returning the default value if the user enters nothing.
+	 */
+	Choice(ctx context.Context, message string, choices []string, defaultValue string) (string, error)
+}
+
+type prompterKey struct{}
+
+/*
+ * Returns a context that carries the given prompter. Remotes invoked with this context (directly or through the plugin
+ * interface) can retrieve it via PrompterFromContext().
+ */
+func WithPrompter(ctx context.Context, prompter Prompter) context.Context {
+	return context.WithValue(ctx, prompterKey{}, prompter)
+}
+
+/*
+ * Returns the prompter attached to the context, or nil if there is none. Remotes should treat a missing prompter as a
+ * non-interactive session, and fail if the information they need can't be found elsewhere.
+ */
+func PrompterFromContext(ctx context.Context) Prompter {
+	prompter, _ := ctx.Value(prompterKey{}).(Prompter)
+	return prompter
+}
+
+/*
+ * A prompter that answers from a fixed script of responses, for testing remotes that prompt. Each prompt consumes the
+ * next response in order, with an empty response selecting the default. Confirmations accept "y", "yes", "n", or
+ * "no", and choices must match one of the offered choices. Prompting beyond the end of the script fails.
+ */
+type ScriptedPrompter struct {
+	mu        sync.Mutex
+	responses []string
+	prompts   []string
+}
+
+func NewScriptedPrompter(responses ...string) *ScriptedPrompter {
+	return &ScriptedPrompter{responses: responses}
+}
+
+/*
+ * Returns the messages of all prompts made so far, in order.
+ */
+func (p *ScriptedPrompter) Prompts() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string{}, p.prompts...)
+}
+
+/*
+ * Returns the number of responses that have yet to be consumed.
+ */
+func (p *ScriptedPrompter) Remaining() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.responses)
+}
+
+func (p *ScriptedPrompter) next(message string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prompts = append(p.prompts, message)
+	if len(p.responses) == 0 {
+		return "", fmt.Errorf("no scripted response for prompt '%s'", message)
+	}
+	response := p.responses[0]
+	p.responses = p.responses[1:]
+	return response, nil
+}
+
+func (p *ScriptedPrompter) Text(ctx context.Context, message string, defaultValue string) (string, error) {
+	response, err := p.next(message)
+	if err != nil {
+		return "", err
+	}
+	if response == "" {
+		return defaultValue, nil
+	}
+	return response, nil
+}
+
+func (p *ScriptedPrompter) Secret(ctx context.Context, message string) (string, error) {
+	return p.next(message)
+}
+
+func (p *ScriptedPrompter) Confirm(ctx context.Context, message string, defaultValue bool) (bool, error) {
+	response, err := p.next(message)
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(response) {
+	case "":
+		return defaultValue, nil
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid response '%s' for prompt '%s', must be yes or no", response, message)
+}
+
+func (p *ScriptedPrompter) Choice(ctx context.Context, message string, choices []string, defaultValue string) (string, error) {
+	response, err := p.next(message)
+	if err != nil {
+		return "", err
+	}
+	if response == "" {
+		response = defaultValue
+	}
+	if !contains(choices, response) {
+		return "", fmt.Errorf("invalid response '%s' for prompt '%s', must be one of %v", response, message, choices)
+	}
+	return response, nil
+}
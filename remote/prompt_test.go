@@ -0,0 +1,166 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"errors"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/assert"
+	proto "github.com/titan-data/remote-sdk-go/internal/proto"
+	"testing"
+)
+
+/*
+ * A context-aware remote whose GetParameters() prompts for a user, password, confirmation, and region. All other
+ * methods are left unimplemented.
+ */
+type promptingRemote struct {
+	RemoteWithContext
+}
+
+func (r promptingRemote) GetParameters(ctx context.Context, properties map[string]interface{}) (map[string]interface{}, error) {
+	p := PrompterFromContext(ctx)
+	if p == nil {
+		return nil, Errorf(ErrUnsupported, "no prompter")
+	}
+	user, err := p.Text(ctx, "User", "root")
+	if err != nil {
+		return nil, err
+	}
+	password, err := p.Secret(ctx, "Password")
+	if err != nil {
+		return nil, err
+	}
+	save, err := p.Confirm(ctx, "Save password?", false)
+	if err != nil {
+		return nil, err
+	}
+	region, err := p.Choice(ctx, "Region", []string{"us-east-1", "us-west-2"}, "us-east-1")
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"user": user, "password": password, "save": save, "region": region}, nil
+}
+
+func TestPrompterContext(t *testing.T) {
+	assert.Nil(t, PrompterFromContext(context.Background()))
+	p := NewScriptedPrompter()
+	assert.Equal(t, p, PrompterFromContext(WithPrompter(context.Background(), p)))
+}
+
+func TestScriptedPrompter(t *testing.T) {
+	p := NewScriptedPrompter("", "secret", "yes", "us-west-2")
+	params, err := promptingRemote{}.GetParameters(WithPrompter(context.Background(), p), nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{
+			"user":     "root",
+			"password": "secret",
+			"save":     true,
+			"region":   "us-west-2",
+		}, params)
+	}
+	assert.Equal(t, []string{"User", "Password", "Save password?", "Region"}, p.Prompts())
+	assert.Equal(t, 0, p.Remaining())
+}
+
+func TestScriptedPrompterDefaults(t *testing.T) {
+	ctx := context.Background()
+	p := NewScriptedPrompter("", "", "N")
+	ok, err := p.Confirm(ctx, "Continue?", true)
+	if assert.NoError(t, err) {
+		assert.True(t, ok)
+	}
+	choice, err := p.Choice(ctx, "Pick", []string{"a", "b"}, "b")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "b", choice)
+	}
+	ok, err = p.Confirm(ctx, "Continue?", true)
+	if assert.NoError(t, err) {
+		assert.False(t, ok)
+	}
+}
+
+func TestScriptedPrompterInvalid(t *testing.T) {
+	ctx := context.Background()
+	p := NewScriptedPrompter("maybe", "c")
+	_, err := p.Confirm(ctx, "Continue?", false)
+	if assert.Error(t, err) {
+		assert.Equal(t, "invalid response 'maybe' for prompt 'Continue?', must be yes or no", err.Error())
+	}
+	_, err = p.Choice(ctx, "Pick", []string{"a", "b"}, "")
+	if assert.Error(t, err) {
+		assert.Equal(t, "invalid response 'c' for prompt 'Pick', must be one of [a b]", err.Error())
+	}
+}
+
+func TestScriptedPrompterExhausted(t *testing.T) {
+	_, err := NewScriptedPrompter().Secret(context.Background(), "Password")
+	if assert.Error(t, err) {
+		assert.Equal(t, "no scripted response for prompt 'Password'", err.Error())
+	}
+}
+
+func dispensePrompting(t *testing.T) (RemoteWithContext, func()) {
+	client, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		"remote": &remotePlugin{Impl: WithoutContext(promptingRemote{}), version: ProtocolVersion},
+	})
+	raw, err := client.Dispense("remote")
+	if !assert.NoError(t, err) {
+		client.Close()
+		server.Stop()
+		return nil, func() {}
+	}
+	return raw.(RemoteWithContext), func() {
+		client.Close()
+		server.Stop()
+	}
+}
+
+func TestPluginPrompt(t *testing.T) {
+	r, done := dispensePrompting(t)
+	defer done()
+	if assert.NotNil(t, r) {
+		p := NewScriptedPrompter("admin", "secret", "", "")
+		params, err := r.GetParameters(WithPrompter(context.Background(), p), map[string]interface{}{})
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]interface{}{
+				"user":     "admin",
+				"password": "secret",
+				"save":     false,
+				"region":   "us-east-1",
+			}, params)
+		}
+		assert.Equal(t, []string{"User", "Password", "Save password?", "Region"}, p.Prompts())
+	}
+}
+
+func TestPluginPromptFailure(t *testing.T) {
+	r, done := dispensePrompting(t)
+	defer done()
+	if assert.NotNil(t, r) {
+		_, err := r.GetParameters(WithPrompter(context.Background(), NewScriptedPrompter("admin")), map[string]interface{}{})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "no scripted response for prompt 'Password'")
+		}
+	}
+}
+
+func TestPluginPromptNonInteractive(t *testing.T) {
+	r, done := dispensePrompting(t)
+	defer done()
+	if assert.NotNil(t, r) {
+		ctx := WithProgress(context.Background(), ProgressFunc(func(p Progress) {}))
+		_, err := r.GetParameters(ctx, map[string]interface{}{})
+		if assert.Error(t, err) {
+			assert.Equal(t, "no prompter", err.Error())
+		}
+	}
+}
+
+func TestHostPromptUnsupported(t *testing.T) {
+	s := &hostRPCServer{host: newHostServices(nil)}
+	_, err := s.Prompt(context.Background(), &proto.PromptRequest{CallId: "1", Message: "User"})
+	assert.True(t, errors.Is(decodeError(err), ErrUnsupported))
+}
@@ -0,0 +1,370 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+/*
+ * A set of registered and loaded remotes. The package-level functions such as Register(), Get() and Load() operate on
+ * a default registry, but independent registries can be created with NewRegistry(), for example to isolate tests
+ * that run in parallel. All operations are safe for concurrent use.
+ */
+type Registry struct {
+	opts       options
+	mu         sync.RWMutex
+	loading    map[string]*sync.Mutex
+	registered map[string]Remote
+	loaded     map[string]*supervisor
+	inProcess  map[string]*inProcessPlugin
+}
+
+/*
+ * Configuration of a registry.
+ */
+type options struct {
+	logOptions
+	searchPath      []string
+	trustedKeys     []ed25519.PublicKey
+	healthInterval  time.Duration
+	healthTimeout   time.Duration
+	healthThreshold int
+}
+
+/*
+ * Configures a registry created by NewRegistry(). Logging options are of type LogOption, which can also be passed to
+ * Serve().
+ */
+type Option interface {
+	apply(*options)
+}
+
+type optionFunc func(*options)
+
+func (f optionFunc) apply(o *options) {
+	f(o)
+}
+
+/*
+ * Search the given directories for plugins, rather than DefaultSearchPath().
+ */
+func WithSearchPath(dirs ...string) Option {
+	return optionFunc(func(o *options) {
+		o.searchPath = dirs
+	})
+}
+
+var defaultRegistry = NewRegistry()
+
+func newOptions(opts []Option) options {
+	o := options{
+		healthInterval:  defaultHealthInterval,
+		healthTimeout:   defaultHealthTimeout,
+		healthThreshold: defaultHealthThreshold,
+		logOptions:      newLogOptions(nil),
+	}
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+/*
+ * Create a new, empty registry.
+ */
+func NewRegistry(opts ...Option) *Registry {
+	return &Registry{
+		opts:       newOptions(opts),
+		loading:    map[string]*sync.Mutex{},
+		registered: map[string]Remote{},
+		loaded:     map[string]*supervisor{},
+		inProcess:  map[string]*inProcessPlugin{},
+	}
+}
+
+/*
+ * Register a new remote, replacing any existing remote of the same type. Panics if the type of the remote cannot be
+ * determined.
+ */
+func (r *Registry) Register(remote Remote) {
+	remoteType, err := remote.Type()
+	if err != nil {
+		panic(err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.registered[remoteType] = remote
+}
+
+/*
+ * Register a new context-aware remote.
+ */
+func (r *Registry) RegisterWithContext(remote RemoteWithContext) {
+	r.Register(WithoutContext(remote))
+}
+
+/*
+ * Get a remote by type, or nil if no such remote is available. See Lookup().
+ */
+func (r *Registry) Get(remoteType string) Remote {
+	remote, _ := r.Lookup(remoteType)
+	return remote
+}
+
+/*
+ * Get a remote by type. Registered remotes take precedence, followed by those already loaded. Otherwise, the plugin
+ * for the remote is found on the search path and loaded. Fails with ErrNotFound if there is no such plugin, or
+ * ErrInvalidType if the type is not a valid name, in which case the search path is never consulted.
+ */
+func (r *Registry) Lookup(remoteType string) (Remote, error) {
+	if remote := r.getRegistered(remoteType); remote != nil {
+		return remote, nil
+	}
+	if s := r.getLoaded(remoteType); s != nil {
+		return WithoutContext(supervisedRemote{s}), nil
+	}
+	if p := r.getInProcess(remoteType); p != nil {
+		return WithoutContext(p.client), nil
+	}
+
+	if err := validateType(remoteType); err != nil {
+		return nil, err
+	}
+	p, err := FindPlugin(r.SearchPath(), remoteType)
+	if err != nil {
+		return nil, err
+	}
+	return r.load(remoteType, p.Path)
+}
+
+/*
+ * Get the remote that handles URLs with the given scheme, returning its type. A remote whose type is the scheme takes
+ * precedence, followed by registered remotes and then plugins on the search path that list the scheme in their
+ * manifest.
+ */
+func (r *Registry) lookupScheme(scheme string) (string, Remote, error) {
+	remote, err := r.Lookup(scheme)
+	if !errors.Is(err, ErrNotFound) {
+		return scheme, remote, err
+	}
+
+	r.mu.RLock()
+	types := make([]string, 0, len(r.registered))
+	for t := range r.registered {
+		types = append(types, t)
+	}
+	r.mu.RUnlock()
+	sort.Strings(types)
+	for _, t := range types {
+		registered := r.getRegistered(t)
+		if m, ok := underlying(registered).(ManifestRemote); ok && contains(m.Manifest().Schemes, scheme) {
+			return t, registered, nil
+		}
+	}
+
+	for _, p := range r.Discover() {
+		if p.Manifest != nil && contains(p.Manifest.Schemes, scheme) {
+			remote, err := r.load(p.Type, p.Path)
+			return p.Type, remote, err
+		}
+	}
+	return "", nil, err
+}
+
+/*
+ * Returns the directories searched for plugins.
+ */
+func (r *Registry) SearchPath() []string {
+	if o := r.currentOptions(); o.searchPath != nil {
+		return o.searchPath
+	}
+	return DefaultSearchPath()
+}
+
+/*
+ * Find all plugins on the search path.
+ */
+func (r *Registry) Discover() []Plugin {
+	return Discover(r.SearchPath())
+}
+
+/*
+ * Returns the types of all registered and loaded remotes, in sorted order.
+ */
+func (r *Registry) List() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ret := []string{}
+	for t := range r.registered {
+		ret = append(ret, t)
+	}
+	for t := range r.loaded {
+		if _, ok := r.registered[t]; !ok {
+			ret = append(ret, t)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+/*
+ * Load a remote via the plugin interface, as with the package-level Load(). The plugin binary is named either after
+ * the remote type or following the discovery naming convention. Concurrent loads of the same remote share a single
+ * plugin process, which is supervised: if it exits or fails its health check, it is restarted transparently.
+ */
+func (r *Registry) Load(remoteType string, pluginPath string) (Remote, error) {
+	binary, err := pluginBinary(pluginPath, remoteType)
+	if err != nil {
+		return nil, err
+	}
+	return r.load(remoteType, binary)
+}
+
+func (r *Registry) load(remoteType string, binary string) (Remote, error) {
+	if s := r.getLoaded(remoteType); s != nil {
+		return WithoutContext(supervisedRemote{s}), nil
+	}
+	if p := r.getInProcess(remoteType); p != nil {
+		return WithoutContext(p.client), nil
+	}
+
+	lock := r.loadLock(remoteType)
+	lock.Lock()
+	defer lock.Unlock()
+	if s := r.getLoaded(remoteType); s != nil {
+		return WithoutContext(supervisedRemote{s}), nil
+	}
+	if p := r.getInProcess(remoteType); p != nil {
+		return WithoutContext(p.client), nil
+	}
+
+	s, err := newSupervisor(r, remoteType, binary, r.getRegistered(remoteType))
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.loaded[remoteType] = s
+	return WithoutContext(supervisedRemote{s}), nil
+}
+
+/*
+ * Returns the lock held while loading the given remote type, so that concurrent loads of one type launch a single
+ * plugin process without blocking loads of other types.
+ */
+func (r *Registry) loadLock(remoteType string) *sync.Mutex {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	lock, ok := r.loading[remoteType]
+	if !ok {
+		lock = &sync.Mutex{}
+		r.loading[remoteType] = lock
+	}
+	return lock
+}
+
+/*
+ * Returns the plugin protocol version negotiated with a loaded or in-process remote, or zero if the remote is not
+ * loaded.
+ */
+func (r *Registry) NegotiatedVersion(remoteType string) int {
+	if s := r.getLoaded(remoteType); s != nil {
+		return s.version()
+	}
+	if p := r.getInProcess(remoteType); p != nil {
+		return p.client.version
+	}
+	return 0
+}
+
+/*
+ * Returns the status of a loaded plugin or in-process remote, or false if the remote is not loaded.
+ */
+func (r *Registry) Status(remoteType string) (PluginStatus, bool) {
+	if s := r.getLoaded(remoteType); s != nil {
+		return s.status(), true
+	}
+	if p := r.getInProcess(remoteType); p != nil {
+		return p.status(), true
+	}
+	return PluginStatus{}, false
+}
+
+/*
+ * Unload a remote previously loaded via Load(), stopping its plugin process.
+ */
+func (r *Registry) Unload(remoteType string) {
+	r.mu.Lock()
+	s, ok := r.loaded[remoteType]
+	p := r.inProcess[remoteType]
+	delete(r.loaded, remoteType)
+	delete(r.inProcess, remoteType)
+	delete(r.loading, remoteType)
+	r.mu.Unlock()
+	if ok {
+		s.close()
+	}
+	if p != nil {
+		p.close()
+	}
+}
+
+/*
+ * Clear any registered or loaded remotes, stopping all plugin processes.
+ */
+func (r *Registry) Clear() {
+	r.mu.Lock()
+	loaded, inProcess := r.loaded, r.inProcess
+	r.registered = map[string]Remote{}
+	r.loading = map[string]*sync.Mutex{}
+	r.loaded = map[string]*supervisor{}
+	r.inProcess = map[string]*inProcessPlugin{}
+	r.mu.Unlock()
+	for _, s := range loaded {
+		s.close()
+	}
+	for _, p := range inProcess {
+		p.close()
+	}
+}
+
+/*
+ * Apply additional options to the registry. Options only affect plugins loaded afterwards.
+ */
+func (r *Registry) Configure(opts ...Option) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, opt := range opts {
+		opt.apply(&r.opts)
+	}
+}
+
+func (r *Registry) currentOptions() options {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.opts
+}
+
+func (r *Registry) getRegistered(remoteType string) Remote {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.registered[remoteType]
+}
+
+func (r *Registry) getLoaded(remoteType string) *supervisor {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.loaded[remoteType]
+}
+
+func (r *Registry) getInProcess(remoteType string) *inProcessPlugin {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.inProcess[remoteType]
+}
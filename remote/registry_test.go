@@ -0,0 +1,118 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func mockRemote(remoteType string) *MockRemote {
+	r := new(MockRemote)
+	r.On("Type").Return(remoteType)
+	return r
+}
+
+func TestRegistryIsolated(t *testing.T) {
+	for i := 0; i < 4; i++ {
+		remoteType := fmt.Sprintf("mock%d", i)
+		t.Run(remoteType, func(t *testing.T) {
+			t.Parallel()
+			reg := NewRegistry()
+			r := mockRemote(remoteType)
+			reg.Register(r)
+			assert.Equal(t, r, reg.Get(remoteType))
+			assert.Equal(t, []string{remoteType}, reg.List())
+			assert.Nil(t, Get(remoteType))
+		})
+	}
+}
+
+func TestRegistryRegisterWithContext(t *testing.T) {
+	reg := NewRegistry()
+	r := mockRemote("mock")
+	reg.RegisterWithContext(WithContext(r))
+	assert.Equal(t, r, reg.Get("mock"))
+}
+
+func TestRegistryGetMissing(t *testing.T) {
+	assert.Nil(t, NewRegistry().Get("mock"))
+	assert.Empty(t, NewRegistry().List())
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	reg := NewRegistry()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			remoteType := fmt.Sprintf("mock%d", i%5)
+			reg.Register(mockRemote(remoteType))
+			assert.NotNil(t, reg.Get(remoteType))
+			reg.List()
+			reg.Unload(remoteType)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, []string{"mock0", "mock1", "mock2", "mock3", "mock4"}, reg.List())
+}
+
+func TestRegistryClear(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register(mockRemote("mock"))
+	reg.loadLock("mock")
+	reg.Clear()
+	assert.Nil(t, reg.Get("mock"))
+	assert.Empty(t, reg.List())
+	assert.Empty(t, reg.loading)
+}
+
+func TestRegistryLoad(t *testing.T) {
+	reg := NewRegistry()
+	defer reg.Clear()
+
+	var wg sync.WaitGroup
+	remotes := make([]Remote, 5)
+	for i := range remotes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r, err := reg.Load("echo", "../build")
+			assert.NoError(t, err)
+			remotes[i] = r
+		}(i)
+	}
+	wg.Wait()
+
+	for _, r := range remotes[1:] {
+		assert.Equal(t, remotes[0], r)
+	}
+	assert.Equal(t, []string{"echo"}, reg.List())
+	assert.Equal(t, ProtocolVersion, reg.NegotiatedVersion("echo"))
+
+	reg.Unload("echo")
+	assert.Empty(t, reg.List())
+	assert.Equal(t, 0, reg.NegotiatedVersion("echo"))
+}
+
+func TestRegistryLoadPerType(t *testing.T) {
+	reg := NewRegistry()
+	defer reg.Clear()
+
+	lock := reg.loadLock("slow")
+	lock.Lock()
+	defer lock.Unlock()
+	_, err := reg.Load("echo", "../build")
+	assert.NoError(t, err)
+}
+
+func TestRegistryLoadMissing(t *testing.T) {
+	reg := NewRegistry()
+	_, err := reg.Load("missing", "../build")
+	assert.Error(t, err)
+	assert.Empty(t, reg.List())
+}
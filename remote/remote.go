@@ -5,8 +5,8 @@ package remote
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 	"github.com/titan-data/remote-sdk-go/internal/proto"
 	"google.golang.org/grpc"
@@ -55,7 +55,8 @@ type Remote interface {
 	/*
 	 * Given a set of remote properties, return a set of parameter properties that will be passed to each operation.
 	 * This is invoked in the context of the user CLI. It can access user data, such as SSH or AWS configuration. It
-	 * can also interactively prompt the user for additional input (such as a password).
+	 * can also interactively prompt the user for additional input (such as a password), via the prompter attached to
+	 * the context of RemoteWithContext.GetParameters() (see PrompterFromContext()).
 	 */
 	GetParameters(properties map[string]interface{}) (map[string]interface{}, error)
 
@@ -77,8 +78,9 @@ type Remote interface {
 	 * in RemoteServerUtil if remotes don't provide this functionality server-side. Tags are specified as a list of
 	 * pairs, where the first element is always the key and the second is optionally the value.
 	 *
-	 * There is not yet support for pagination, though that will be added in the future to avoid having to fetch
-	 * the entire commit history every time.
+	 * This returns the entire commit history. Remotes that can fetch commits incrementally should also implement
+	 * PaginatedRemote or IterableRemote, and callers can use ListCommitsPage() or IterateCommits() to avoid holding
+	 * the entire history at once.
 	 */
 	ListCommits(properties map[string]interface{}, parameters map[string]interface{}, tags []Tag) ([]Commit, error)
 
@@ -88,56 +90,115 @@ type Remote interface {
 	GetCommit(properties map[string]interface{}, parameters map[string]interface{}, commitId string) (*Commit, error)
 }
 
+/*
+ * Version of the plugin protocol implemented by this SDK. Version 1 covers URL handling and commit metadata, version
+ * 2 adds pagination, streaming, operations, volume transfers, and capabilities, version 3 adds property schemas, and
+ * version 4 adds health checks. Hosts and plugins negotiate the highest version they both support, so that new hosts
+ * can load old plugins and vice versa.
+ */
+const ProtocolVersion = 4
+
 type remotePlugin struct {
 	plugin.NetRPCUnsupportedPlugin
-	Impl Remote
+	Impl     Remote
+	version  int
+	redactor *Redactor
 }
 
 func (p *remotePlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
-	remote.RegisterRemoteServer(s, &remoteRPCServer{Impl: p.Impl})
+	remote.RegisterRemoteServer(s, newRemoteRPCServer(p.Impl, broker, p.redactor))
 	return nil
 }
 
-func (remotePlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
-	return &remoteRPCClient{Client: remote.NewRemoteClient(c)}, nil
+func (p *remotePlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return newRemoteRPCClient(c, broker, p.version, p.redactor), nil
 }
 
-type loadedRemote struct {
-	r Remote
-	c *plugin.Client
+/*
+ * Create the plugin side of a remote, serving the given implementation. If no redactor is given, one is created from
+ * the remote's schema.
+ */
+func newRemoteRPCServer(impl Remote, broker hostBroker, redactor *Redactor) *remoteRPCServer {
+	if redactor == nil {
+		redactor = remoteRedactor(context.Background(), impl)
+	}
+	return &remoteRPCServer{
+		Impl:         WithContext(impl),
+		host:         newHostConnections(broker),
+		capabilities: detectCapabilities(impl),
+		redactor:     redactor,
+	}
 }
 
-var registeredRemotes = map[string]Remote{}
-var loadedRemotes = map[string]loadedRemote{}
+/*
+ * Create the host side of a remote, calling a plugin that negotiated the given protocol version.
+ */
+func newRemoteRPCClient(c *grpc.ClientConn, broker hostBroker, version int, redactor *Redactor) *remoteRPCClient {
+	return &remoteRPCClient{
+		Client:   remote.NewRemoteClient(c),
+		host:     newHostServices(broker),
+		version:  version,
+		redactor: redactor,
+	}
+}
+
+/*
+ * Returns the plugin sets for every supported protocol version. The same implementation serves all versions, as newer
+ * versions only add RPCs. The redactor is shared with the logger, so that secrets seen in calls are also redacted
+ * from log output.
+ */
+func pluginSets(impl Remote, redactor *Redactor) map[int]plugin.PluginSet {
+	sets := map[int]plugin.PluginSet{}
+	for v := 1; v <= ProtocolVersion; v++ {
+		sets[v] = plugin.PluginSet{
+			"remote": &remotePlugin{Impl: impl, version: v, redactor: redactor},
+		}
+	}
+	return sets
+}
+
+type loadedRemote struct {
+	r       Remote
+	c       *plugin.Client
+	version int
+}
 
 /*
  * Register a new remote. This should be called from the init() function of a remote implementation. The remotes can
  * later be accessed via the Get() method.
  */
 func Register(remote Remote) {
-	remoteType, error := remote.Type()
-	if error != nil {
-		panic(error)
-	}
-	registeredRemotes[remoteType] = remote
+	defaultRegistry.Register(remote)
 }
 
 /*
- * Get a remote by type.
+ * Get a remote by type. If no remote of the type has been registered or loaded, then its plugin is discovered on the
+ * search path and loaded.
  */
 func Get(remoteType string) Remote {
-	return registeredRemotes[remoteType]
+	return defaultRegistry.Get(remoteType)
+}
+
+/*
+ * Get a remote by type as with Get(), but returning the reason why the remote could not be found or loaded.
+ */
+func Lookup(remoteType string) (Remote, error) {
+	return defaultRegistry.Lookup(remoteType)
+}
+
+/*
+ * Apply options to the default registry, used by the package-level functions. Options only affect plugins loaded
+ * afterwards.
+ */
+func Configure(opts ...Option) {
+	defaultRegistry.Configure(opts...)
 }
 
 /*
  * Clear any registered or loaded remotes. Should only be used for testing.
  */
 func Clear() {
-	registeredRemotes = map[string]Remote{}
-	for _, v := range loadedRemotes {
-		v.c.Kill()
-	}
-	loadedRemotes = map[string]loadedRemote{}
+	defaultRegistry.Clear()
 }
 
 var handshakeConfig = plugin.HandshakeConfig{
@@ -147,51 +208,124 @@ var handshakeConfig = plugin.HandshakeConfig{
 }
 
 /*
- * Run the remote as a plugin server, to be invoked from the main method of the remote implementation.
+ * Run the remote as a plugin server, to be invoked from the main method of the remote implementation. Sensitive
+ * properties, as declared by the remote's schema, are redacted from URLs, errors, and log output. By default, errors
+ * are logged to stderr as JSON, from which they are forwarded to the host's logger. If the plugin is run with the
+ * "--manifest" argument, its manifest is written to stdout instead. The remote must have been registered; otherwise the
+ * plugin exits with an error.
  */
-func Serve(remoteType string) {
-	logger := hclog.New(&hclog.LoggerOptions{
-		Name:   "remote",
-		Output: os.Stdout,
-		Level:  hclog.Error,
-	})
+func Serve(remoteType string, opts ...LogOption) {
+	if len(os.Args) > 1 && os.Args[1] == "--manifest" {
+		if err := ServeManifest(remoteType, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 
-	remote := Get(remoteType)
-	var pluginMap = map[string]plugin.Plugin{
-		"remote": &remotePlugin{Impl: remote},
+	impl, err := servedRemote(remoteType)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
+	redactor := remoteRedactor(context.Background(), impl)
+	o := newLogOptions(opts)
+	logger := o.newLogger(os.Stderr, true, redactor)
 
 	plugin.Serve(&plugin.ServeConfig{
-		HandshakeConfig: handshakeConfig,
-		Plugins:         pluginMap,
-		GRPCServer:      plugin.DefaultGRPCServer,
-		Logger:          logger,
+		HandshakeConfig:  handshakeConfig,
+		VersionedPlugins: pluginSets(impl, redactor),
+		GRPCServer:       newGRPCServer,
+		Logger:           logger,
 	})
 }
 
 /*
- * Load a remote via the plugin interface. These plugins will remain loaded until Unload() or Clear() is called.
+ * Returns the registered remote served by this plugin. Unlike Get(), this never searches for a plugin, as a plugin
+ * that failed to register its remote would otherwise find and launch itself, or another binary, in its place.
+ */
+func servedRemote(remoteType string) (Remote, error) {
+	if r := defaultRegistry.getRegistered(remoteType); r != nil {
+		return r, nil
+	}
+	return nil, Errorf(ErrNotFound, "no remote '%s' is registered", remoteType)
+}
+
+/*
+ * Returned by Load() when a plugin reports a different remote type than the one requested, or negotiates a protocol
+ * version that it doesn't declare in its manifest.
+ */
+var ErrPluginMismatch = errors.New("plugin mismatch")
+
+/*
+ * Load a remote via the plugin interface, from a binary within pluginPath named either "<remoteType>" or
+ * "titan-remote-<remoteType>". These plugins will remain loaded until Unload() or Clear() is called. The
+ * returned remote can be passed to WithContext() to get a variant whose contexts are propagated to the plugin. If the
+ * plugin provides a schema, its sensitive properties are redacted from URLs and from the plugin's log output. Fails
+ * with ErrPluginMismatch if the plugin reports a different type than the one requested. If the remote has been served
+ * with LoadInProcess(), that remote is returned instead.
  */
 func Load(remoteType string, pluginPath string) (Remote, error) {
-	if v, ok := loadedRemotes[remoteType]; ok {
-		return v.r, nil
+	return defaultRegistry.Load(remoteType, pluginPath)
+}
+
+/*
+ * Returns the plugin protocol version negotiated with a loaded or in-process remote, or zero if the remote is not
+ * loaded.
+ */
+func NegotiatedVersion(remoteType string) int {
+	return defaultRegistry.NegotiatedVersion(remoteType)
+}
+
+/*
+ * Returns the status of a loaded plugin, including how many times it has been restarted and why it last failed, or
+ * false if the remote is not loaded. Remotes served with LoadInProcess() are always reported as running.
+ */
+func Status(remoteType string) (PluginStatus, bool) {
+	return defaultRegistry.Status(remoteType)
+}
+
+func Unload(remoteType string) {
+	defaultRegistry.Unload(remoteType)
+}
+
+/*
+ * Validate and verify the given plugin binary against its manifest, then launch it and connect to it. Only the
+ * protocol versions listed in the manifest are offered to the plugin.
+ */
+func (r *Registry) loadPlugin(remoteType string, binary string, impl Remote) (loadedRemote, error) {
+	manifest, err := ReadManifest(binary)
+	if err != nil {
+		return loadedRemote{}, err
+	}
+	if manifest != nil {
+		if err := manifest.Validate(remoteType); err != nil {
+			return loadedRemote{}, fmt.Errorf("failed to load plugin '%s': %w", binary, err)
+		}
+	}
+	o := r.currentOptions()
+	secure, err := verifyPlugin(binary, manifest, o.trustedKeys)
+	if err != nil {
+		return loadedRemote{}, err
 	}
 
-	logger := hclog.New(&hclog.LoggerOptions{
-		Name:   "remote",
-		Output: os.Stdout,
-		Level:  hclog.Error,
-	})
+	redactor := NewRedactor()
+	logger := o.newLogger(os.Stdout, false, redactor).With("remote", remoteType)
 
-	remote := Get(remoteType)
-	var pluginMap = map[string]plugin.Plugin{
-		"remote": &remotePlugin{Impl: remote},
+	sets := pluginSets(impl, redactor)
+	if manifest != nil && len(manifest.Protocols) != 0 {
+		for v := range sets {
+			if !containsInt(manifest.Protocols, v) {
+				delete(sets, v)
+			}
+		}
 	}
 
 	client := plugin.NewClient(&plugin.ClientConfig{
 		HandshakeConfig:  handshakeConfig,
-		Plugins:          pluginMap,
-		Cmd:              exec.Command(fmt.Sprintf("%s/%s", pluginPath, remoteType)),
+		VersionedPlugins: sets,
+		Cmd:              exec.Command(binary),
+		SecureConfig:     secure,
 		Logger:           logger,
 		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
 	})
@@ -199,26 +333,57 @@ func Load(remoteType string, pluginPath string) (Remote, error) {
 	rpcClient, err := client.Client()
 	if err != nil {
 		client.Kill()
-		return nil, err
+		return loadedRemote{}, err
 	}
 
 	raw, err := rpcClient.Dispense("remote")
 	if err != nil {
 		client.Kill()
-		return nil, err
+		return loadedRemote{}, err
 	}
 
-	loadedRemotes[remoteType] = loadedRemote{
-		r: raw.(Remote),
-		c: client,
+	loaded := WithoutContext(raw.(RemoteWithContext))
+	actualType, err := loaded.Type()
+	if err == nil {
+		err = checkPlugin(binary, remoteType, actualType, client.NegotiatedVersion(), manifest)
+	}
+	if err != nil {
+		client.Kill()
+		return loadedRemote{}, err
 	}
+	if schema, err := GetSchema(context.Background(), loaded); err == nil {
+		redactor.AddSensitive(SensitiveProperties(schema)...)
+	}
+	return loadedRemote{
+		r:       loaded,
+		c:       client,
+		version: client.NegotiatedVersion(),
+	}, nil
+}
 
-	return raw.(Remote), nil
+/*
+ * Check that a launched plugin is for the requested remote type, and that it negotiated a protocol version that it
+ * declares in its manifest.
+ */
+func checkPlugin(binary string, remoteType string, actualType string, version int, manifest *Manifest) error {
+	if actualType != remoteType {
+		return Errorf(ErrPluginMismatch, "plugin '%s' is for remote '%s', not '%s'", binary, actualType, remoteType)
+	}
+	if version < 1 || version > ProtocolVersion {
+		return Errorf(ErrPluginMismatch, "plugin '%s' negotiated unsupported protocol version %d", binary, version)
+	}
+	if manifest != nil && len(manifest.Protocols) != 0 && !containsInt(manifest.Protocols, version) {
+		return Errorf(ErrPluginMismatch, "plugin '%s' negotiated protocol version %d, but its manifest declares %v",
+			binary, version, manifest.Protocols)
+	}
+	return nil
 }
 
-func Unload(remoteType string) {
-	if val, ok := loadedRemotes[remoteType]; ok {
-		val.c.Kill()
-		delete(loadedRemotes, remoteType)
+func containsInt(arr []int, search int) bool {
+	for _, v := range arr {
+		if v == search {
+			return true
+		}
 	}
+	return false
 }
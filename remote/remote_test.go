@@ -4,7 +4,9 @@
 package remote
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
+	"os"
 	"testing"
 )
 
@@ -19,3 +21,46 @@ func TestRegister(t *testing.T) {
 	assert.Equal(t, "mock", typ)
 	r.AssertExpectations(t)
 }
+
+func TestLoadTypeMismatch(t *testing.T) {
+	dir := linkEcho(t, "s3")
+	defer os.RemoveAll(dir)
+	reg := NewRegistry()
+	defer reg.Clear()
+
+	_, err := reg.Load("s3", dir)
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, ErrPluginMismatch))
+		assert.Contains(t, err.Error(), "is for remote 'echo', not 's3'")
+	}
+	assert.Empty(t, reg.List())
+}
+
+func TestLoadManifestProtocols(t *testing.T) {
+	dir, binary := copyEcho(t)
+	defer os.RemoveAll(dir)
+	writeManifest(t, binary, Manifest{Type: "echo", Protocols: []int{1, 2}})
+	reg := NewRegistry()
+	defer reg.Clear()
+
+	_, err := reg.Load("echo", dir)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, reg.NegotiatedVersion("echo"))
+	}
+}
+
+func TestCheckPlugin(t *testing.T) {
+	assert.NoError(t, checkPlugin("p", "echo", "echo", ProtocolVersion, nil))
+	assert.NoError(t, checkPlugin("p", "echo", "echo", 1, &Manifest{Type: "echo", Protocols: []int{1}}))
+
+	err := checkPlugin("p", "echo", "s3", 1, nil)
+	assert.True(t, errors.Is(err, ErrPluginMismatch))
+
+	err = checkPlugin("p", "echo", "echo", ProtocolVersion+1, nil)
+	assert.True(t, errors.Is(err, ErrPluginMismatch))
+
+	err = checkPlugin("p", "echo", "echo", 2, &Manifest{Type: "echo", Protocols: []int{1}})
+	if assert.True(t, errors.Is(err, ErrPluginMismatch)) {
+		assert.Equal(t, "plugin 'p' negotiated protocol version 2, but its manifest declares [1]", err.Error())
+	}
+}
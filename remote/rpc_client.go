@@ -7,81 +7,144 @@ import (
 	"context"
 	proto "github.com/titan-data/remote-sdk-go/internal/proto"
 	"github.com/titan-data/remote-sdk-go/internal/util"
+	"io"
 )
 
 type remoteRPCClient struct {
-	Client proto.RemoteClient
+	Client   proto.RemoteClient
+	host     *hostServices
+	version  int
+	redactor *Redactor
 }
 
-func (r remoteRPCClient) Type() (string, error) {
+/*
+ * Fail calls that require a newer protocol version than the plugin supports, rather than invoking an RPC that the
+ * plugin doesn't implement.
+ */
+func (r remoteRPCClient) require(version int, feature string) error {
+	if r.version < version {
+		return Errorf(ErrUnsupported, "%s requires plugin protocol version %d, but plugin only supports version %d",
+			feature, version, r.version)
+	}
+	return nil
+}
+
+func (r remoteRPCClient) Type(ctx context.Context) (string, error) {
 	req := proto.GetTypeRequest{}
-	res, err := r.Client.GetType(context.Background(), &req)
+	res, err := r.Client.GetType(ctx, &req)
 	if err != nil {
-		return "", err
+		return "", decodeError(err)
 	}
 	return res.Type, nil
 }
 
-func (r remoteRPCClient) FromURL(url string, properties map[string]string) (map[string]interface{}, error) {
+func (r remoteRPCClient) FromURL(ctx context.Context, url string, properties map[string]string) (map[string]interface{}, error) {
 	req := proto.FromURLRequest{Url: url, Properties: properties}
-	res, err := r.Client.FromURL(context.Background(), &req)
+	res, err := r.Client.FromURL(ctx, &req)
 	if err != nil {
-		return nil, err
+		return nil, decodeError(err)
 	}
 	output, err := util.Struct2Map(res.Remote)
 	if err != nil {
 		return nil, err
 	}
+	r.redactor.Observe(output)
 	return output, nil
 }
 
-func (r remoteRPCClient) ToURL(properties map[string]interface{}) (string, map[string]string, error) {
+func (r remoteRPCClient) ToURL(ctx context.Context, properties map[string]interface{}) (string, map[string]string, error) {
 	s, err := util.Map2Struct(properties)
 	if err != nil {
 		return "", nil, err
 	}
+	r.redactor.Observe(properties)
 	req := proto.ToURLRequest{Remote: s}
-	res, err := r.Client.ToURL(context.Background(), &req)
+	res, err := r.Client.ToURL(ctx, &req)
 	if err != nil {
-		return "", nil, err
+		return "", nil, decodeError(err)
 	}
-	return res.Url, res.Properties, nil
+	return r.redactor.Redact(res.Url), r.redactor.RedactProperties(res.Properties), nil
 }
 
-func (r remoteRPCClient) GetParameters(properties map[string]interface{}) (map[string]interface{}, error) {
+func (r remoteRPCClient) GetParameters(ctx context.Context, properties map[string]interface{}) (map[string]interface{}, error) {
 	p, err := util.Map2Struct(properties)
 	if err != nil {
 		return nil, err
 	}
+	r.redactor.Observe(properties)
 	req := proto.GetParametersRequest{Remote: p}
-	res, err := r.Client.GetParameters(context.Background(), &req)
+	ctx, done := r.host.attach(ctx)
+	defer done()
+	res, err := r.Client.GetParameters(ctx, &req)
+	if err != nil {
+		return nil, decodeError(err)
+	}
+	output, err := util.Struct2Map(res.Parameters)
 	if err != nil {
 		return nil, err
 	}
-	return util.Struct2Map(res.Parameters)
+	r.redactor.Observe(output)
+	return output, nil
 }
 
-func (r remoteRPCClient) ValidateRemote(properties map[string]interface{}) error {
+func (r remoteRPCClient) ValidateRemote(ctx context.Context, properties map[string]interface{}) error {
 	p, err := util.Map2Struct(properties)
 	if err != nil {
 		return err
 	}
 	req := proto.ValidateRemoteRequest{Remote: p}
-	_, err = r.Client.ValidateRemote(context.Background(), &req)
-	return err
+	_, err = r.Client.ValidateRemote(ctx, &req)
+	return decodeError(err)
 }
 
-func (r remoteRPCClient) ValidateParameters(parameters map[string]interface{}) error {
+func (r remoteRPCClient) ValidateParameters(ctx context.Context, parameters map[string]interface{}) error {
 	p, err := util.Map2Struct(parameters)
 	if err != nil {
 		return err
 	}
 	req := proto.ValidateParametersRequest{Parameters: p}
-	_, err = r.Client.ValidateParameters(context.Background(), &req)
-	return err
+	_, err = r.Client.ValidateParameters(ctx, &req)
+	return decodeError(err)
+}
+
+func (r remoteRPCClient) ListCommits(ctx context.Context, properties map[string]interface{}, parameters map[string]interface{}, tags []Tag) ([]Commit, error) {
+	input, err := listCommitRequest(properties, parameters, tags)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.Client.ListCommits(ctx, input)
+	if err != nil {
+		return nil, decodeError(err)
+	}
+	return commitsFromProto(res.Commits)
+}
+
+func (r remoteRPCClient) ListCommitsPage(ctx context.Context, properties map[string]interface{}, parameters map[string]interface{}, tags []Tag, page PageRequest) (CommitPage, error) {
+	if r.require(2, "pagination") != nil {
+		commits, err := r.ListCommits(ctx, properties, parameters, tags)
+		if err != nil {
+			return CommitPage{}, err
+		}
+		return slicePage(commits, page)
+	}
+	input, err := listCommitRequest(properties, parameters, tags)
+	if err != nil {
+		return CommitPage{}, err
+	}
+	input.PageSize = int32(page.Size)
+	input.PageToken = page.Token
+	res, err := r.Client.ListCommits(ctx, input)
+	if err != nil {
+		return CommitPage{}, decodeError(err)
+	}
+	commits, err := commitsFromProto(res.Commits)
+	if err != nil {
+		return CommitPage{}, err
+	}
+	return CommitPage{Commits: commits, NextToken: res.NextPageToken}, nil
 }
 
-func (r remoteRPCClient) ListCommits(properties map[string]interface{}, parameters map[string]interface{}, tags []Tag) ([]Commit, error) {
+func listCommitRequest(properties map[string]interface{}, parameters map[string]interface{}, tags []Tag) (*proto.ListCommitRequest, error) {
 	remote, err := util.Map2Struct(properties)
 	if err != nil {
 		return nil, err
@@ -104,30 +167,98 @@ func (r remoteRPCClient) ListCommits(properties map[string]interface{}, paramete
 			}
 		}
 	}
-	input := proto.ListCommitRequest{
+	return &proto.ListCommitRequest{
 		Remote:     remote,
 		Parameters: params,
 		Tags:       rpcTags,
+	}, nil
+}
+
+func (r remoteRPCClient) IterateCommits(ctx context.Context, properties map[string]interface{}, parameters map[string]interface{}, tags []Tag) (CommitIterator, error) {
+	if r.require(2, "streaming") != nil {
+		commits, err := r.ListCommits(ctx, properties, parameters, tags)
+		if err != nil {
+			return nil, err
+		}
+		return &sliceIterator{commits: commits, pos: -1}, nil
 	}
-	res, err := r.Client.ListCommits(context.Background(), &input)
+	input, err := listCommitRequest(properties, parameters, tags)
 	if err != nil {
 		return nil, err
 	}
-	nativeCommits := make([]Commit, len(res.Commits))
-	for i, c := range res.Commits {
-		props, err := util.Struct2Map(c.Properties)
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := r.Client.StreamCommits(ctx, input)
+	if err != nil {
+		cancel()
+		return nil, decodeError(err)
+	}
+	return &streamIterator{stream: stream, cancel: cancel}, nil
+}
+
+func commitsFromProto(commits []*proto.Commit) ([]Commit, error) {
+	nativeCommits := make([]Commit, len(commits))
+	for i, c := range commits {
+		commit, err := commitFromProto(c)
 		if err != nil {
 			return nil, err
 		}
-		nativeCommits[i] = Commit{
-			Id:         c.Id,
-			Properties: props,
-		}
+		nativeCommits[i] = commit
 	}
 	return nativeCommits, nil
 }
 
-func (r remoteRPCClient) GetCommit(properties map[string]interface{}, parameters map[string]interface{}, commitId string) (*Commit, error) {
+func commitFromProto(commit *proto.Commit) (Commit, error) {
+	props, err := util.Struct2Map(commit.Properties)
+	if err != nil {
+		return Commit{}, err
+	}
+	return Commit{
+		Id:         commit.Id,
+		Properties: props,
+	}, nil
+}
+
+type streamIterator struct {
+	stream proto.Remote_StreamCommitsClient
+	cancel context.CancelFunc
+	commit Commit
+	done   bool
+	err    error
+}
+
+func (i *streamIterator) Next() bool {
+	if i.done {
+		return false
+	}
+	c, err := i.stream.Recv()
+	if err == nil {
+		i.commit, err = commitFromProto(c)
+	}
+	if err != nil {
+		if err != io.EOF {
+			i.err = decodeError(err)
+		}
+		i.Close()
+		return false
+	}
+	return true
+}
+
+func (i *streamIterator) Commit() Commit {
+	return i.commit
+}
+
+func (i *streamIterator) Err() error {
+	return i.err
+}
+
+func (i *streamIterator) Close() error {
+	i.done = true
+	i.cancel()
+	return nil
+}
+
+func (r remoteRPCClient) GetCommit(ctx context.Context, properties map[string]interface{}, parameters map[string]interface{}, commitId string) (*Commit, error) {
 	remote, err := util.Map2Struct(properties)
 	if err != nil {
 		return nil, err
@@ -141,9 +272,9 @@ func (r remoteRPCClient) GetCommit(properties map[string]interface{}, parameters
 		Parameters: params,
 		CommitId:   commitId,
 	}
-	res, err := r.Client.GetCommit(context.Background(), &input)
+	res, err := r.Client.GetCommit(ctx, &input)
 	if err != nil {
-		return nil, err
+		return nil, decodeError(err)
 	}
 	if res.GetCommitNull() {
 		return nil, nil
@@ -155,3 +286,289 @@ func (r remoteRPCClient) GetCommit(properties map[string]interface{}, parameters
 		return &Commit{Id: commitId, Properties: props}, nil
 	}
 }
+
+func (r remoteRPCClient) StartOperation(ctx context.Context, operation Operation) (map[string]interface{}, error) {
+	if err := r.require(2, "operations"); err != nil {
+		return nil, err
+	}
+	ctx, done := r.host.attach(ctx)
+	defer done()
+	op, err := operationToProto(operation)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.Client.StartOperation(ctx, &proto.StartOperationRequest{Operation: op})
+	if err != nil {
+		return nil, decodeError(err)
+	}
+	if res.Data == nil {
+		return nil, nil
+	}
+	return util.Struct2Map(res.Data)
+}
+
+func (r remoteRPCClient) PushMetadata(ctx context.Context, operation Operation, commit Commit, isUpdate bool) error {
+	if err := r.require(2, "operations"); err != nil {
+		return err
+	}
+	ctx, done := r.host.attach(ctx)
+	defer done()
+	op, err := operationToProto(operation)
+	if err != nil {
+		return err
+	}
+	c, err := commitToProto(commit)
+	if err != nil {
+		return err
+	}
+	_, err = r.Client.PushMetadata(ctx, &proto.PushMetadataRequest{Operation: op, Commit: c, IsUpdate: isUpdate})
+	return decodeError(err)
+}
+
+func (r remoteRPCClient) SyncVolume(ctx context.Context, operation Operation, volume Volume) error {
+	if err := r.require(2, "operations"); err != nil {
+		return err
+	}
+	ctx, done := r.host.attach(ctx)
+	defer done()
+	op, err := operationToProto(operation)
+	if err != nil {
+		return err
+	}
+	props, err := util.Map2Struct(volume.Properties)
+	if err != nil {
+		return err
+	}
+	vol := proto.Volume{
+		Name:        volume.Name,
+		Properties:  props,
+		Path:        volume.Path,
+		ScratchPath: volume.ScratchPath,
+	}
+	_, err = r.Client.SyncVolume(ctx, &proto.SyncVolumeRequest{Operation: op, Volume: &vol})
+	return decodeError(err)
+}
+
+func (r remoteRPCClient) EndOperation(ctx context.Context, operation Operation) error {
+	if err := r.require(2, "operations"); err != nil {
+		return err
+	}
+	ctx, done := r.host.attach(ctx)
+	defer done()
+	op, err := operationToProto(operation)
+	if err != nil {
+		return err
+	}
+	_, err = r.Client.EndOperation(ctx, &proto.EndOperationRequest{Operation: op})
+	return decodeError(err)
+}
+
+func (r remoteRPCClient) FailOperation(ctx context.Context, operation Operation, reason string) error {
+	if err := r.require(2, "operations"); err != nil {
+		return err
+	}
+	ctx, done := r.host.attach(ctx)
+	defer done()
+	op, err := operationToProto(operation)
+	if err != nil {
+		return err
+	}
+	_, err = r.Client.FailOperation(ctx, &proto.FailOperationRequest{Operation: op, Reason: reason})
+	return decodeError(err)
+}
+
+func operationToProto(operation Operation) (*proto.Operation, error) {
+	remote, err := util.Map2Struct(operation.Remote)
+	if err != nil {
+		return nil, err
+	}
+	params, err := util.Map2Struct(operation.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	data, err := util.Map2Struct(operation.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.Operation{
+		Id:         operation.Id,
+		Type:       string(operation.Type),
+		CommitId:   operation.CommitId,
+		Remote:     remote,
+		Parameters: params,
+		Data:       data,
+	}, nil
+}
+
+func (r remoteRPCClient) UploadVolume(ctx context.Context, operation Operation, volume string, reader io.Reader) error {
+	_, err := r.UploadVolumeAt(ctx, operation, volume, 0, reader)
+	return err
+}
+
+func (r remoteRPCClient) UploadVolumeAt(ctx context.Context, operation Operation, volume string, offset int64, reader io.Reader) (int64, error) {
+	if err := r.require(2, "volume transfers"); err != nil {
+		return 0, err
+	}
+	ctx, done := r.host.attach(ctx)
+	defer done()
+	op, err := operationToProto(operation)
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := r.Client.UploadVolume(ctx)
+	if err != nil {
+		return 0, decodeError(err)
+	}
+	w := &chunkWriter{send: stream.Send, header: &proto.VolumeChunk{Operation: op, Volume: volume}, offset: offset}
+	_, err = io.CopyBuffer(w, reader, make([]byte, chunkSize))
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	// If the server aborted the stream, sending fails with io.EOF and the real error is returned here
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return 0, decodeError(err)
+	}
+	// The remote must have consumed everything that was sent, or data has been silently dropped
+	if res.Offset != w.offset {
+		return 0, Errorf(ErrDataLoss, "remote committed volume '%s' up to offset %d, but data was sent up to offset %d",
+			volume, res.Offset, w.offset)
+	}
+	return res.Offset, nil
+}
+
+func (r remoteRPCClient) DownloadVolume(ctx context.Context, operation Operation, volume string, w io.Writer) error {
+	return r.DownloadVolumeAt(ctx, operation, volume, 0, w)
+}
+
+func (r remoteRPCClient) DownloadVolumeAt(ctx context.Context, operation Operation, volume string, offset int64, w io.Writer) error {
+	if err := r.require(2, "volume transfers"); err != nil {
+		return err
+	}
+	ctx, done := r.host.attach(ctx)
+	defer done()
+	op, err := operationToProto(operation)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	req := proto.DownloadVolumeRequest{Operation: op, Volume: volume, Offset: offset}
+	stream, err := r.Client.DownloadVolume(ctx, &req)
+	if err != nil {
+		return decodeError(err)
+	}
+	recv := func() (*proto.VolumeChunk, error) {
+		chunk, err := stream.Recv()
+		return chunk, decodeError(err)
+	}
+	_, err = io.Copy(w, &chunkReader{recv: recv, offset: offset})
+	return err
+}
+
+func (r remoteRPCClient) VolumeOffset(ctx context.Context, operation Operation, volume string) (int64, error) {
+	if err := r.require(2, "volume transfers"); err != nil {
+		return 0, err
+	}
+	op, err := operationToProto(operation)
+	if err != nil {
+		return 0, err
+	}
+	res, err := r.Client.GetVolumeOffset(ctx, &proto.GetVolumeOffsetRequest{Operation: op, Volume: volume})
+	if err != nil {
+		return 0, decodeError(err)
+	}
+	return res.Offset, nil
+}
+
+func (r remoteRPCClient) capabilities(ctx context.Context) (Capabilities, error) {
+	if err := r.require(2, "capabilities"); err != nil {
+		return Capabilities{}, err
+	}
+	res, err := r.Client.GetCapabilities(ctx, &proto.GetCapabilitiesRequest{})
+	if err != nil {
+		return Capabilities{}, decodeError(err)
+	}
+	return Capabilities{
+		Cancellation:      res.Cancellation,
+		Pagination:        res.Pagination,
+		Streaming:         res.Streaming,
+		TagFiltering:      res.TagFiltering,
+		Operations:        res.Operations,
+		VolumeTransfer:    res.VolumeTransfer,
+		ResumableTransfer: res.ResumableTransfer,
+		Schema:            res.Schema,
+		Delete:            res.Delete,
+	}, nil
+}
+
+func (r remoteRPCClient) Schema(ctx context.Context) (Schema, error) {
+	if err := r.require(3, "schemas"); err != nil {
+		return Schema{}, err
+	}
+	res, err := r.Client.GetSchema(ctx, &proto.GetSchemaRequest{})
+	if err != nil {
+		return Schema{}, decodeError(err)
+	}
+	remote, err := propertiesFromProto(res.Remote)
+	if err != nil {
+		return Schema{}, err
+	}
+	params, err := propertiesFromProto(res.Parameters)
+	if err != nil {
+		return Schema{}, err
+	}
+	return Schema{Remote: remote, Parameters: params}, nil
+}
+
+func propertiesFromProto(properties []*proto.PropertySchema) ([]Property, error) {
+	nativeProperties := make([]Property, len(properties))
+	for i, p := range properties {
+		def, err := util.Value2Interface(p.Default)
+		if err != nil {
+			return nil, err
+		}
+		var nested []Property
+		if len(p.Properties) != 0 {
+			if nested, err = propertiesFromProto(p.Properties); err != nil {
+				return nil, err
+			}
+		}
+		var items *Property
+		if p.Items != nil {
+			nativeItems, err := propertiesFromProto([]*proto.PropertySchema{p.Items})
+			if err != nil {
+				return nil, err
+			}
+			items = &nativeItems[0]
+		}
+		nativeProperties[i] = Property{
+			Name:        p.Name,
+			Type:        PropertyType(p.Type),
+			Required:    p.Required,
+			Default:     def,
+			Sensitive:   p.Sensitive,
+			Description: p.Description,
+			Enum:        p.Enum,
+			Pattern:     p.Pattern,
+			Properties:  nested,
+			Items:       items,
+		}
+	}
+	return nativeProperties, nil
+}
+
+func (r remoteRPCClient) Health(ctx context.Context) error {
+	if r.require(4, "health checks") != nil {
+		// Older plugins can only show that they are responsive
+		_, err := r.Type(ctx)
+		return err
+	}
+	_, err := r.Client.Health(ctx, &proto.HealthRequest{})
+	return decodeError(err)
+}
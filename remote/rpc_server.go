@@ -4,25 +4,99 @@
 package remote
 
 import (
+	"bufio"
 	"context"
+	_struct "github.com/golang/protobuf/ptypes/struct"
 	proto "github.com/titan-data/remote-sdk-go/internal/proto"
 	"github.com/titan-data/remote-sdk-go/internal/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+/*
+ * Create the gRPC server for a plugin, encoding errors returned by the remote so that their kind is preserved. Secrets
+ * are redacted from errors before they are encoded.
+ */
+func newGRPCServer(opts []grpc.ServerOption) *grpc.Server {
+	opts = append(opts, grpc.UnaryInterceptor(encodeUnaryErrors), grpc.StreamInterceptor(encodeStreamErrors))
+	return grpc.NewServer(opts...)
+}
+
+func encodeUnaryErrors(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	s, _ := info.Server.(*remoteRPCServer)
+	if s != nil {
+		s.observe(req)
+	}
+	res, err := handler(ctx, req)
+	if s != nil {
+		s.observe(res)
+		err = s.redactor.RedactError(err)
+	}
+	return res, encodeError(err)
+}
+
+func encodeStreamErrors(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, ss)
+	if s, ok := srv.(*remoteRPCServer); ok {
+		err = s.redactor.RedactError(err)
+	}
+	return encodeError(err)
+}
+
 type remoteRPCServer struct {
-	Impl Remote
+	Impl         RemoteWithContext
+	host         *hostConnections
+	capabilities Capabilities
+	redactor     *Redactor
 }
 
-func (r *remoteRPCServer) GetType(context.Context, *proto.GetTypeRequest) (*proto.GetTypeResponse, error) {
-	typ, err := r.Impl.Type()
+/*
+ * Record the values of any sensitive properties carried by a request or response, so that they are redacted from
+ * subsequent errors and log output.
+ */
+func (r *remoteRPCServer) observe(msg interface{}) {
+	var structs []*_struct.Struct
+	if m, ok := msg.(interface{ GetRemote() *_struct.Struct }); ok {
+		structs = append(structs, m.GetRemote())
+	}
+	if m, ok := msg.(interface{ GetParameters() *_struct.Struct }); ok {
+		structs = append(structs, m.GetParameters())
+	}
+	if m, ok := msg.(interface{ GetOperation() *proto.Operation }); ok {
+		structs = append(structs, m.GetOperation().GetRemote(), m.GetOperation().GetParameters())
+	}
+	for _, s := range structs {
+		if props, err := util.Struct2Map(s); err == nil {
+			r.redactor.Observe(props)
+		}
+	}
+}
+
+func (r *remoteRPCServer) GetType(ctx context.Context, req *proto.GetTypeRequest) (*proto.GetTypeResponse, error) {
+	typ, err := r.Impl.Type(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return &proto.GetTypeResponse{Type: typ}, nil
 }
 
+func (r *remoteRPCServer) GetCapabilities(ctx context.Context, req *proto.GetCapabilitiesRequest) (*proto.GetCapabilitiesResponse, error) {
+	return &proto.GetCapabilitiesResponse{
+		Cancellation:      r.capabilities.Cancellation,
+		Pagination:        r.capabilities.Pagination,
+		Streaming:         r.capabilities.Streaming,
+		TagFiltering:      r.capabilities.TagFiltering,
+		Operations:        r.capabilities.Operations,
+		VolumeTransfer:    r.capabilities.VolumeTransfer,
+		ResumableTransfer: r.capabilities.ResumableTransfer,
+		Schema:            r.capabilities.Schema,
+		Delete:            r.capabilities.Delete,
+	}, nil
+}
+
 func (r *remoteRPCServer) FromURL(ctx context.Context, req *proto.FromURLRequest) (*proto.FromURLResponse, error) {
-	props, err := r.Impl.FromURL(req.Url, req.Properties)
+	props, err := r.Impl.FromURL(ctx, req.Url, req.Properties)
 	if err != nil {
 		return nil, err
 	}
@@ -38,11 +112,11 @@ func (r *remoteRPCServer) ToURL(ctx context.Context, req *proto.ToURLRequest) (*
 	if err != nil {
 		return nil, err
 	}
-	url, props, err := r.Impl.ToURL(input)
+	url, props, err := r.Impl.ToURL(ctx, input)
 	if err != nil {
 		return nil, err
 	}
-	ret := &proto.ToURLResponse{Url: url, Properties: props}
+	ret := &proto.ToURLResponse{Url: r.redactor.Redact(url), Properties: r.redactor.RedactProperties(props)}
 	return ret, nil
 }
 
@@ -51,7 +125,7 @@ func (r *remoteRPCServer) GetParameters(ctx context.Context, req *proto.GetParam
 	if err != nil {
 		return nil, err
 	}
-	props, err := r.Impl.GetParameters(input)
+	props, err := r.Impl.GetParameters(r.host.context(ctx), input)
 	if err != nil {
 		return nil, err
 	}
@@ -67,7 +141,7 @@ func (r *remoteRPCServer) ValidateRemote(ctx context.Context, req *proto.Validat
 	if err != nil {
 		return nil, err
 	}
-	err = r.Impl.ValidateRemote(remote)
+	err = r.Impl.ValidateRemote(ctx, remote)
 	return &proto.ValidateRemoteResponse{}, err
 }
 
@@ -76,7 +150,7 @@ func (r *remoteRPCServer) ValidateParameters(ctx context.Context, req *proto.Val
 	if err != nil {
 		return nil, err
 	}
-	err = r.Impl.ValidateParameters(params)
+	err = r.Impl.ValidateParameters(ctx, params)
 	return &proto.ValidateParametersResponse{}, err
 }
 
@@ -89,8 +163,34 @@ func (r *remoteRPCServer) ListCommits(ctx context.Context, req *proto.ListCommit
 	if err != nil {
 		return nil, err
 	}
-	nativeTags := make([]Tag, len(req.Tags))
-	for i, t := range req.Tags {
+	nativeTags := tagsFromProto(req.Tags)
+
+	var commits []Commit
+	var nextToken string
+	if req.PageSize > 0 || req.PageToken != "" {
+		page, err := listCommitsPage(ctx, r.Impl, remote, params, nativeTags, PageRequest{Size: int(req.PageSize), Token: req.PageToken})
+		if err != nil {
+			return nil, err
+		}
+		commits = page.Commits
+		nextToken = page.NextToken
+	} else {
+		commits, err = r.Impl.ListCommits(ctx, remote, params, nativeTags)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rpcCommits, err := commitsToProto(commits)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ListCommitResponse{Commits: rpcCommits, NextPageToken: nextToken}, nil
+}
+
+func tagsFromProto(tags []*proto.Tag) []Tag {
+	nativeTags := make([]Tag, len(tags))
+	for i, t := range tags {
 		if t.GetValueNull() {
 			nativeTags[i] = Tag{Key: t.Key}
 		} else {
@@ -98,24 +198,30 @@ func (r *remoteRPCServer) ListCommits(ctx context.Context, req *proto.ListCommit
 			nativeTags[i] = Tag{Key: t.Key, Value: &val}
 		}
 	}
-	commits, err := r.Impl.ListCommits(remote, params, nativeTags)
-	if err != nil {
-		return nil, err
-	}
+	return nativeTags
+}
 
+func commitsToProto(commits []Commit) ([]*proto.Commit, error) {
 	rpcCommits := make([]*proto.Commit, len(commits))
 	for i, c := range commits {
-		props, err := util.Map2Struct(c.Properties)
+		rpcCommit, err := commitToProto(c)
 		if err != nil {
 			return nil, err
 		}
-		rpcCommits[i] = &proto.Commit{
-			Id:         c.Id,
-			Properties: props,
-		}
+		rpcCommits[i] = rpcCommit
 	}
+	return rpcCommits, nil
+}
 
-	return &proto.ListCommitResponse{Commits: rpcCommits}, nil
+func commitToProto(commit Commit) (*proto.Commit, error) {
+	props, err := util.Map2Struct(commit.Properties)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.Commit{
+		Id:         commit.Id,
+		Properties: props,
+	}, nil
 }
 
 func (r *remoteRPCServer) GetCommit(ctx context.Context, req *proto.GetCommitRequest) (*proto.GetCommitResponse, error) {
@@ -127,7 +233,7 @@ func (r *remoteRPCServer) GetCommit(ctx context.Context, req *proto.GetCommitReq
 	if err != nil {
 		return nil, err
 	}
-	commit, err := r.Impl.GetCommit(remote, params, req.CommitId)
+	commit, err := r.Impl.GetCommit(ctx, remote, params, req.CommitId)
 	if err != nil {
 		return nil, err
 	}
@@ -149,3 +255,320 @@ func (r *remoteRPCServer) GetCommit(ctx context.Context, req *proto.GetCommitReq
 		}, nil
 	}
 }
+
+func (r *remoteRPCServer) StreamCommits(req *proto.ListCommitRequest, stream proto.Remote_StreamCommitsServer) error {
+	remote, err := util.Struct2Map(req.Remote)
+	if err != nil {
+		return err
+	}
+	params, err := util.Struct2Map(req.Parameters)
+	if err != nil {
+		return err
+	}
+	iter, err := iterateCommits(stream.Context(), r.Impl, remote, params, tagsFromProto(req.Tags))
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	for iter.Next() {
+		rpcCommit, err := commitToProto(iter.Commit())
+		if err != nil {
+			return err
+		}
+		if err = stream.Send(rpcCommit); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
+func (r *remoteRPCServer) operations() (OperationRemote, error) {
+	if o, ok := underlying(r.Impl).(OperationRemote); ok {
+		return o, nil
+	}
+	return nil, Errorf(ErrUnsupported, "remote does not support operations")
+}
+
+func (r *remoteRPCServer) StartOperation(ctx context.Context, req *proto.StartOperationRequest) (*proto.StartOperationResponse, error) {
+	ctx = r.host.context(ctx)
+	o, err := r.operations()
+	if err != nil {
+		return nil, err
+	}
+	op, err := operationFromProto(req.Operation)
+	if err != nil {
+		return nil, err
+	}
+	data, err := o.StartOperation(ctx, op)
+	if err != nil {
+		return nil, err
+	}
+	output, err := util.Map2Struct(data)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.StartOperationResponse{Data: output}, nil
+}
+
+func (r *remoteRPCServer) PushMetadata(ctx context.Context, req *proto.PushMetadataRequest) (*proto.PushMetadataResponse, error) {
+	ctx = r.host.context(ctx)
+	o, err := r.operations()
+	if err != nil {
+		return nil, err
+	}
+	op, err := operationFromProto(req.Operation)
+	if err != nil {
+		return nil, err
+	}
+	if req.Commit == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing commit")
+	}
+	commit, err := commitFromProto(req.Commit)
+	if err != nil {
+		return nil, err
+	}
+	err = o.PushMetadata(ctx, op, commit, req.IsUpdate)
+	return &proto.PushMetadataResponse{}, err
+}
+
+func (r *remoteRPCServer) SyncVolume(ctx context.Context, req *proto.SyncVolumeRequest) (*proto.SyncVolumeResponse, error) {
+	ctx = r.host.context(ctx)
+	o, err := r.operations()
+	if err != nil {
+		return nil, err
+	}
+	op, err := operationFromProto(req.Operation)
+	if err != nil {
+		return nil, err
+	}
+	if req.Volume == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing volume")
+	}
+	props, err := util.Struct2Map(req.Volume.Properties)
+	if err != nil {
+		return nil, err
+	}
+	volume := Volume{
+		Name:        req.Volume.Name,
+		Properties:  props,
+		Path:        req.Volume.Path,
+		ScratchPath: req.Volume.ScratchPath,
+	}
+	err = o.SyncVolume(ctx, op, volume)
+	return &proto.SyncVolumeResponse{}, err
+}
+
+func (r *remoteRPCServer) EndOperation(ctx context.Context, req *proto.EndOperationRequest) (*proto.EndOperationResponse, error) {
+	ctx = r.host.context(ctx)
+	o, err := r.operations()
+	if err != nil {
+		return nil, err
+	}
+	op, err := operationFromProto(req.Operation)
+	if err != nil {
+		return nil, err
+	}
+	err = o.EndOperation(ctx, op)
+	return &proto.EndOperationResponse{}, err
+}
+
+func (r *remoteRPCServer) FailOperation(ctx context.Context, req *proto.FailOperationRequest) (*proto.FailOperationResponse, error) {
+	ctx = r.host.context(ctx)
+	o, err := r.operations()
+	if err != nil {
+		return nil, err
+	}
+	op, err := operationFromProto(req.Operation)
+	if err != nil {
+		return nil, err
+	}
+	err = o.FailOperation(ctx, op, req.Reason)
+	return &proto.FailOperationResponse{}, err
+}
+
+func operationFromProto(operation *proto.Operation) (Operation, error) {
+	if operation == nil {
+		return Operation{}, status.Error(codes.InvalidArgument, "missing operation")
+	}
+	remote, err := util.Struct2Map(operation.Remote)
+	if err != nil {
+		return Operation{}, err
+	}
+	params, err := util.Struct2Map(operation.Parameters)
+	if err != nil {
+		return Operation{}, err
+	}
+	data, err := util.Struct2Map(operation.Data)
+	if err != nil {
+		return Operation{}, err
+	}
+	return Operation{
+		Id:         operation.Id,
+		Type:       OperationType(operation.Type),
+		CommitId:   operation.CommitId,
+		Remote:     remote,
+		Parameters: params,
+		Data:       data,
+	}, nil
+}
+
+func (r *remoteRPCServer) transfers() (VolumeTransferRemote, error) {
+	if t, ok := underlying(r.Impl).(VolumeTransferRemote); ok {
+		return t, nil
+	}
+	return nil, Errorf(ErrUnsupported, "remote does not support volume transfers")
+}
+
+func (r *remoteRPCServer) resumableTransfers() (ResumableTransferRemote, error) {
+	if t, ok := underlying(r.Impl).(ResumableTransferRemote); ok {
+		return t, nil
+	}
+	return nil, Errorf(ErrUnsupported, "remote does not support resumable transfers")
+}
+
+func (r *remoteRPCServer) UploadVolume(stream proto.Remote_UploadVolumeServer) error {
+	ctx := r.host.context(stream.Context())
+	t, err := r.transfers()
+	if err != nil {
+		return err
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	op, err := operationFromProto(first.Operation)
+	if err != nil {
+		return err
+	}
+	if err = verifyChunk(first, first.Offset); err != nil {
+		return err
+	}
+	reader := &chunkReader{recv: stream.Recv, buf: first.Data, offset: first.Offset}
+
+	if rt, ok := t.(ResumableTransferRemote); ok {
+		committed, err := rt.UploadVolumeAt(ctx, op, first.Volume, first.Offset, reader)
+		if err != nil {
+			return err
+		}
+		return stream.SendAndClose(&proto.UploadVolumeResponse{Offset: committed})
+	}
+
+	if first.Offset != 0 {
+		return Errorf(ErrUnsupported, "remote does not support resumable transfers")
+	}
+	if err = t.UploadVolume(ctx, op, first.Volume, reader); err != nil {
+		return err
+	}
+	return stream.SendAndClose(&proto.UploadVolumeResponse{Offset: reader.offset})
+}
+
+func (r *remoteRPCServer) DownloadVolume(req *proto.DownloadVolumeRequest, stream proto.Remote_DownloadVolumeServer) error {
+	ctx := r.host.context(stream.Context())
+	t, err := r.transfers()
+	if err != nil {
+		return err
+	}
+	op, err := operationFromProto(req.Operation)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriterSize(&chunkWriter{send: stream.Send, offset: req.Offset}, chunkSize)
+	if req.Offset != 0 {
+		var rt ResumableTransferRemote
+		if rt, err = r.resumableTransfers(); err != nil {
+			return err
+		}
+		err = rt.DownloadVolumeAt(ctx, op, req.Volume, req.Offset, w)
+	} else {
+		err = t.DownloadVolume(ctx, op, req.Volume, w)
+	}
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func (r *remoteRPCServer) GetVolumeOffset(ctx context.Context, req *proto.GetVolumeOffsetRequest) (*proto.GetVolumeOffsetResponse, error) {
+	ctx = r.host.context(ctx)
+	t, err := r.resumableTransfers()
+	if err != nil {
+		return nil, err
+	}
+	op, err := operationFromProto(req.Operation)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := t.VolumeOffset(ctx, op, req.Volume)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.GetVolumeOffsetResponse{Offset: offset}, nil
+}
+
+func (r *remoteRPCServer) GetSchema(ctx context.Context, req *proto.GetSchemaRequest) (*proto.GetSchemaResponse, error) {
+	s, ok := underlying(r.Impl).(SchemaRemote)
+	if !ok {
+		return nil, Errorf(ErrUnsupported, "remote does not provide a schema")
+	}
+	schema, err := s.Schema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := propertiesToProto(schema.Remote)
+	if err != nil {
+		return nil, err
+	}
+	params, err := propertiesToProto(schema.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.GetSchemaResponse{Remote: remote, Parameters: params}, nil
+}
+
+func propertiesToProto(properties []Property) ([]*proto.PropertySchema, error) {
+	rpcProperties := make([]*proto.PropertySchema, len(properties))
+	for i, p := range properties {
+		var def *_struct.Value
+		if p.Default != nil {
+			var err error
+			if def, err = util.Interface2Value(p.Default); err != nil {
+				return nil, err
+			}
+		}
+		nested, err := propertiesToProto(p.Properties)
+		if err != nil {
+			return nil, err
+		}
+		var items *proto.PropertySchema
+		if p.Items != nil {
+			rpcItems, err := propertiesToProto([]Property{*p.Items})
+			if err != nil {
+				return nil, err
+			}
+			items = rpcItems[0]
+		}
+		rpcProperties[i] = &proto.PropertySchema{
+			Name:        p.Name,
+			Type:        string(p.Type),
+			Required:    p.Required,
+			Default:     def,
+			Sensitive:   p.Sensitive,
+			Description: p.Description,
+			Enum:        p.Enum,
+			Pattern:     p.Pattern,
+			Properties:  nested,
+			Items:       items,
+		}
+	}
+	return rpcProperties, nil
+}
+
+func (r *remoteRPCServer) Health(ctx context.Context, req *proto.HealthRequest) (*proto.HealthResponse, error) {
+	if h, ok := underlying(r.Impl).(HealthRemote); ok {
+		if err := h.Health(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return &proto.HealthResponse{}, nil
+}
@@ -4,7 +4,14 @@
 package remote
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
 	"github.com/stretchr/testify/assert"
+	"io"
+	"os/exec"
 	"testing"
 )
 
@@ -125,3 +132,254 @@ func TestGetMissingCommit(t *testing.T) {
 		}
 	}
 }
+
+func TestPluginContextCanceled(t *testing.T) {
+	e := getEcho(t)
+	if assert.NotNil(t, e) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := WithContext(e).Type(ctx)
+		assert.True(t, errors.Is(err, context.Canceled))
+	}
+}
+
+func TestPluginListCommitsPage(t *testing.T) {
+	e := getEcho(t)
+	if assert.NotNil(t, e) {
+		page, err := ListCommitsPage(context.Background(), e, map[string]interface{}{}, map[string]interface{}{}, []Tag{}, PageRequest{Size: 1})
+		if assert.NoError(t, err) {
+			assert.Len(t, page.Commits, 1)
+			assert.Equal(t, "two", page.Commits[0].Id)
+			assert.NotEmpty(t, page.NextToken)
+		}
+		page, err = ListCommitsPage(context.Background(), e, map[string]interface{}{}, map[string]interface{}{}, []Tag{}, PageRequest{Size: 1, Token: page.NextToken})
+		if assert.NoError(t, err) {
+			assert.Len(t, page.Commits, 1)
+			assert.Equal(t, "one", page.Commits[0].Id)
+			assert.Empty(t, page.NextToken)
+		}
+	}
+}
+
+func TestPluginListCommitsPageBadToken(t *testing.T) {
+	e := getEcho(t)
+	if assert.NotNil(t, e) {
+		_, err := ListCommitsPage(context.Background(), e, map[string]interface{}{}, map[string]interface{}{}, []Tag{}, PageRequest{Token: "!!"})
+		if assert.True(t, errors.Is(err, ErrInvalidProperty)) {
+			assert.Equal(t, "invalid page token '!!'", err.Error())
+		}
+	}
+}
+
+func TestPluginIterateCommits(t *testing.T) {
+	e := getEcho(t)
+	if assert.NotNil(t, e) {
+		iter, err := IterateCommits(context.Background(), e, map[string]interface{}{}, map[string]interface{}{}, []Tag{})
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"two", "one"}, collectCommits(t, iter))
+		}
+	}
+}
+
+func TestPluginOperation(t *testing.T) {
+	e := getEcho(t)
+	if assert.NotNil(t, e) {
+		o, ok := Operations(e)
+		if assert.True(t, ok) {
+			op := Operation{
+				Id:         "op",
+				Type:       OperationPush,
+				CommitId:   "commit",
+				Remote:     map[string]interface{}{"a": "b"},
+				Parameters: map[string]interface{}{},
+			}
+			data, err := o.StartOperation(context.Background(), op)
+			if assert.NoError(t, err) {
+				assert.Equal(t, "op", data["operation"])
+			}
+			op.Data = data
+			assert.NoError(t, o.PushMetadata(context.Background(), op, Commit{Id: "commit", Properties: map[string]interface{}{}}, false))
+			err = o.PushMetadata(context.Background(), op, Commit{Id: "other", Properties: map[string]interface{}{}}, true)
+			assert.True(t, errors.Is(err, ErrInvalidProperty))
+			assert.NoError(t, o.SyncVolume(context.Background(), op, Volume{Name: "vol", Path: "/tmp"}))
+			assert.NoError(t, o.EndOperation(context.Background(), op))
+			assert.NoError(t, o.FailOperation(context.Background(), op, "failed"))
+		}
+	}
+}
+
+func TestPluginUploadVolume(t *testing.T) {
+	e := getEcho(t)
+	if assert.NotNil(t, e) {
+		tr, ok := Transfers(e)
+		if assert.True(t, ok) {
+			data := make([]byte, 3*chunkSize+7)
+			err := tr.UploadVolume(context.Background(), Operation{Id: "op"}, "vol", bytes.NewReader(data))
+			assert.NoError(t, err)
+		}
+	}
+}
+
+/*
+ * A remote that stops reading uploads after the first few bytes, but reports success.
+ */
+type truncatingRemote struct {
+	*MockRemote
+}
+
+func (r truncatingRemote) UploadVolume(ctx context.Context, operation Operation, volume string, reader io.Reader) error {
+	_, err := reader.Read(make([]byte, 10))
+	return err
+}
+
+func (r truncatingRemote) DownloadVolume(ctx context.Context, operation Operation, volume string, w io.Writer) error {
+	return nil
+}
+
+func TestPluginUploadVolumeTruncated(t *testing.T) {
+	client, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		"remote": &remotePlugin{Impl: truncatingRemote{new(MockRemote)}, version: ProtocolVersion},
+	})
+	defer server.Stop()
+	defer client.Close()
+	raw, err := client.Dispense("remote")
+	if !assert.NoError(t, err) {
+		return
+	}
+	tr, ok := Transfers(WithoutContext(raw.(RemoteWithContext)))
+	if assert.True(t, ok) {
+		data := make([]byte, 3*chunkSize+7)
+		err := tr.UploadVolume(context.Background(), Operation{Id: "op"}, "vol", bytes.NewReader(data))
+		assert.True(t, errors.Is(err, ErrDataLoss))
+		assert.NoError(t, tr.UploadVolume(context.Background(), Operation{Id: "op"}, "vol", bytes.NewReader(data[:5])))
+	}
+}
+
+func TestPluginDownloadVolume(t *testing.T) {
+	e := getEcho(t)
+	if assert.NotNil(t, e) {
+		tr, ok := Transfers(e)
+		if assert.True(t, ok) {
+			var buf bytes.Buffer
+			err := tr.DownloadVolume(context.Background(), Operation{Id: "op"}, "vol", &buf)
+			if assert.NoError(t, err) {
+				assert.Equal(t, "vol", buf.String())
+			}
+		}
+	}
+}
+
+func TestPluginResumableTransfer(t *testing.T) {
+	e := getEcho(t)
+	if assert.NotNil(t, e) {
+		tr, ok := underlying(e).(ResumableTransferRemote)
+		if assert.True(t, ok) {
+			offset, err := tr.VolumeOffset(context.Background(), Operation{Id: "op"}, "vol")
+			if assert.NoError(t, err) {
+				assert.Equal(t, int64(0), offset)
+			}
+			offset, err = tr.UploadVolumeAt(context.Background(), Operation{Id: "op"}, "vol", 10, bytes.NewReader([]byte("data")))
+			if assert.NoError(t, err) {
+				assert.Equal(t, int64(14), offset)
+			}
+			var buf bytes.Buffer
+			err = tr.DownloadVolumeAt(context.Background(), Operation{Id: "op"}, "volume", 2, &buf)
+			if assert.NoError(t, err) {
+				assert.Equal(t, "lume", buf.String())
+			}
+		}
+	}
+}
+
+func TestPluginProgress(t *testing.T) {
+	e := getEcho(t)
+	if assert.NotNil(t, e) {
+		o, ok := Operations(e)
+		if assert.True(t, ok) {
+			events := []Progress{}
+			ctx := WithProgress(context.Background(), ProgressFunc(func(p Progress) {
+				events = append(events, p)
+			}))
+			op := Operation{Id: "op", Type: OperationPush, CommitId: "commit"}
+			if assert.NoError(t, o.SyncVolume(ctx, op, Volume{Name: "vol"})) {
+				assert.Equal(t, []Progress{{Phase: "push", Message: "vol"}}, events)
+			}
+		}
+	}
+}
+
+func TestPluginCapabilities(t *testing.T) {
+	e := getEcho(t)
+	if assert.NotNil(t, e) {
+		caps, err := GetCapabilities(context.Background(), e)
+		if assert.NoError(t, err) {
+			assert.Equal(t, Capabilities{
+				TagFiltering:      true,
+				Operations:        true,
+				VolumeTransfer:    true,
+				ResumableTransfer: true,
+			}, caps)
+		}
+	}
+}
+
+func TestPluginSchemaUnsupported(t *testing.T) {
+	e := getEcho(t)
+	if assert.NotNil(t, e) {
+		_, err := GetSchema(context.Background(), e)
+		assert.True(t, errors.Is(err, ErrUnsupported))
+	}
+}
+
+func TestPluginHealth(t *testing.T) {
+	e := getEcho(t)
+	if assert.NotNil(t, e) {
+		assert.NoError(t, Health(context.Background(), e))
+	}
+}
+
+func TestPluginNegotiatedVersion(t *testing.T) {
+	e := getEcho(t)
+	if assert.NotNil(t, e) {
+		assert.Equal(t, ProtocolVersion, NegotiatedVersion("echo"))
+		assert.Equal(t, 0, NegotiatedVersion("notloaded"))
+	}
+}
+
+func TestPluginVersion1(t *testing.T) {
+	client := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig:  handshakeConfig,
+		VersionedPlugins: map[int]plugin.PluginSet{1: pluginSets(nil, nil)[1]},
+		Cmd:              exec.Command("../build/echo"),
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		Logger:           hclog.NewNullLogger(),
+	})
+	defer client.Kill()
+	rpcClient, err := client.Client()
+	if !assert.NoError(t, err) {
+		return
+	}
+	raw, err := rpcClient.Dispense("remote")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 1, client.NegotiatedVersion())
+	e := WithoutContext(raw.(RemoteWithContext))
+
+	page, err := ListCommitsPage(context.Background(), e, map[string]interface{}{}, map[string]interface{}{}, []Tag{}, PageRequest{Size: 1})
+	if assert.NoError(t, err) {
+		assert.Len(t, page.Commits, 1)
+		assert.NotEmpty(t, page.NextToken)
+	}
+
+	caps, err := GetCapabilities(context.Background(), e)
+	if assert.NoError(t, err) {
+		assert.Equal(t, Capabilities{}, caps)
+	}
+
+	assert.NoError(t, Health(context.Background(), e))
+
+	o, _ := Operations(e)
+	_, err = o.StartOperation(context.Background(), Operation{Id: "op"})
+	assert.True(t, errors.Is(err, ErrUnsupported))
+}
@@ -0,0 +1,224 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+)
+
+type PropertyType string
+
+const (
+	TypeString  PropertyType = "string"
+	TypeNumber  PropertyType = "number"
+	TypeInteger PropertyType = "integer"
+	TypeBoolean PropertyType = "boolean"
+	TypeObject  PropertyType = "object"
+	TypeArray   PropertyType = "array"
+)
+
+/*
+ * Describes a single remote or parameter property. Sensitive properties (such as passwords or secret keys) must never
+ * be displayed to the user. Enum and pattern only apply to string properties, with the pattern matching the entire
+ * value. Object properties can describe their own nested properties, and array properties the type of their items
+ * (whose name is ignored); if these are not set, then the contents of the object or array are not validated.
+ */
+type Property struct {
+	Name        string
+	Type        PropertyType
+	Required    bool
+	Default     interface{}
+	Sensitive   bool
+	Description string
+	Enum        []string
+	Pattern     string
+	Properties  []Property
+	Items       *Property
+}
+
+/*
+ * Declarative description of the properties accepted by a remote, as returned by FromURL(), and the parameters
+ * returned by GetParameters(). Remotes can implement ValidateRemote() and ValidateParameters() by delegating to the
+ * corresponding methods of their schema.
+ */
+type Schema struct {
+	Remote     []Property
+	Parameters []Property
+}
+
+/*
+ * Optional interface for remotes that can describe their properties.
+ */
+type SchemaRemote interface {
+	Schema(ctx context.Context) (Schema, error)
+}
+
+/*
+ * Returns the schema of the given remote, failing with ErrUnsupported if the remote does not provide one.
+ */
+func GetSchema(ctx context.Context, r Remote) (Schema, error) {
+	if s, ok := underlying(r).(SchemaRemote); ok {
+		return s.Schema(ctx)
+	}
+	return Schema{}, Errorf(ErrUnsupported, "remote does not provide a schema")
+}
+
+/*
+ * Validate remote properties against the schema.
+ */
+func (s Schema) ValidateRemote(properties map[string]interface{}) error {
+	return ValidateProperties(properties, s.Remote)
+}
+
+/*
+ * Validate remote parameters against the schema.
+ */
+func (s Schema) ValidateParameters(parameters map[string]interface{}) error {
+	return ValidateProperties(parameters, s.Parameters)
+}
+
+/*
+ * Validate a set of properties against a schema, checking that all required properties are present, that there are
+ * no unknown properties, and that each value has the correct type and satisfies any enum or pattern constraint.
+ * Nested objects and lists are validated against the properties and items of their schema. All problems are
+ * reported together as a *ValidationError.
+ */
+func ValidateProperties(properties map[string]interface{}, schema []Property) error {
+	v := &ValidationError{}
+	if err := validateObject(v, "", properties, schema); err != nil {
+		return err
+	}
+	return v.Err()
+}
+
+/*
+ * Record problems with the properties in the validation error. Problems with the schema itself, such as an invalid
+ * pattern, are returned as plain errors instead, as they are not the fault of the properties.
+ */
+func validateObject(v *ValidationError, path string, properties map[string]interface{}, schema []Property) error {
+	for _, p := range schema {
+		if _, ok := properties[p.Name]; !ok && p.Required {
+			v.Add(JoinPath(path, p.Name), "missing required property")
+		}
+	}
+
+	for name, value := range properties {
+		if p := findProperty(schema, name); p == nil {
+			v.Add(JoinPath(path, name), "unknown property")
+		} else if err := p.validate(v, JoinPath(path, name), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+ * Returns a copy of the properties with the default value of any missing property filled in.
+ */
+func ApplyDefaults(properties map[string]interface{}, schema []Property) map[string]interface{} {
+	ret := map[string]interface{}{}
+	for k, v := range properties {
+		ret[k] = v
+	}
+	for _, p := range schema {
+		if _, ok := ret[p.Name]; !ok && p.Default != nil {
+			ret[p.Name] = p.Default
+		}
+	}
+	return ret
+}
+
+func findProperty(schema []Property, name string) *Property {
+	for i := range schema {
+		if schema[i].Name == name {
+			return &schema[i]
+		}
+	}
+	return nil
+}
+
+func (p *Property) validate(v *ValidationError, path string, value interface{}) error {
+	if value == nil {
+		if p.Required {
+			v.Add(path, "cannot be null")
+		}
+		return nil
+	}
+
+	if actual := typeOf(value); !p.accepts(actual, value) {
+		v.Add(path, "must be of type %s, but got %s", p.Type, actual)
+		return nil
+	}
+
+	switch val := value.(type) {
+	case string:
+		if len(p.Enum) != 0 && !contains(p.Enum, val) {
+			v.Add(path, "must be one of %v, but got '%s'", p.Enum, val)
+		}
+		if p.Pattern != "" {
+			re, err := regexp.Compile("^(?:" + p.Pattern + ")$")
+			if err != nil {
+				return fmt.Errorf("invalid pattern for property '%s': %v", p.Name, err)
+			}
+			if !re.MatchString(val) {
+				v.Add(path, "must match pattern '%s'", p.Pattern)
+			}
+		}
+	case map[string]interface{}:
+		if p.Properties != nil {
+			return validateObject(v, path, val, p.Properties)
+		}
+	case []interface{}:
+		if p.Items != nil {
+			for i, item := range val {
+				if err := p.Items.validate(v, JoinPath(path, i), item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Property) accepts(actual PropertyType, value interface{}) bool {
+	switch {
+	case p.Type == "" || p.Type == actual:
+		return true
+	case p.Type == TypeNumber && actual == TypeInteger:
+		return true
+	case p.Type == TypeInteger && actual == TypeNumber:
+		// Numbers are always floating point once they've crossed the plugin boundary
+		f := reflect.ValueOf(value).Float()
+		return f == math.Trunc(f)
+	default:
+		return false
+	}
+}
+
+/*
+ * Returns the schema type of a property value.
+ */
+func typeOf(value interface{}) PropertyType {
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.String:
+		return TypeString
+	case reflect.Bool:
+		return TypeBoolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return TypeInteger
+	case reflect.Float32, reflect.Float64:
+		return TypeNumber
+	case reflect.Map, reflect.Struct:
+		return TypeObject
+	case reflect.Slice, reflect.Array:
+		return TypeArray
+	default:
+		return PropertyType(reflect.TypeOf(value).Kind().String())
+	}
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var testSchema = Schema{
+	Remote: []Property{
+		{Name: "host", Type: TypeString, Required: true, Pattern: "[a-z.]+"},
+		{Name: "port", Type: TypeInteger, Default: float64(22)},
+		{Name: "password", Type: TypeString, Sensitive: true},
+		{Name: "mode", Type: TypeString, Enum: []string{"fast", "safe"}},
+	},
+	Parameters: []Property{
+		{Name: "ratio", Type: TypeNumber},
+		{Name: "verbose", Type: TypeBoolean},
+		{Name: "options", Type: TypeObject, Properties: []Property{{Name: "a", Type: TypeString}}},
+		{Name: "keys", Type: TypeArray, Items: &Property{Type: TypeString}},
+	},
+}
+
+type schemaRemote struct {
+	*MockRemote
+}
+
+func (r schemaRemote) Schema(ctx context.Context) (Schema, error) {
+	return testSchema, nil
+}
+
+func TestValidateSchemaSuccess(t *testing.T) {
+	err := testSchema.ValidateRemote(map[string]interface{}{"host": "a.b", "port": 22, "mode": "fast"})
+	assert.NoError(t, err)
+	err = testSchema.ValidateParameters(map[string]interface{}{
+		"ratio":   0.5,
+		"verbose": true,
+		"options": map[string]interface{}{"a": "b"},
+		"keys":    []interface{}{"a"},
+	})
+	assert.NoError(t, err)
+}
+
+func TestValidateSchemaMissing(t *testing.T) {
+	err := testSchema.ValidateRemote(map[string]interface{}{"port": 22})
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, ErrInvalidProperty))
+		assert.Equal(t, "/host: missing required property", err.Error())
+	}
+}
+
+func TestValidateSchemaUnknown(t *testing.T) {
+	err := testSchema.ValidateRemote(map[string]interface{}{"host": "a", "user": "b"})
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, ErrInvalidProperty))
+		assert.Equal(t, "/user: unknown property", err.Error())
+	}
+}
+
+func TestValidateSchemaType(t *testing.T) {
+	err := testSchema.ValidateRemote(map[string]interface{}{"host": "a", "port": "22"})
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, ErrInvalidProperty))
+		assert.Equal(t, "/port: must be of type integer, but got string", err.Error())
+	}
+}
+
+func TestValidateSchemaIntegerFloat(t *testing.T) {
+	assert.NoError(t, testSchema.ValidateRemote(map[string]interface{}{"host": "a", "port": float64(22)}))
+	err := testSchema.ValidateRemote(map[string]interface{}{"host": "a", "port": 22.5})
+	if assert.Error(t, err) {
+		assert.Equal(t, "/port: must be of type integer, but got number", err.Error())
+	}
+}
+
+func TestValidateSchemaNumberInteger(t *testing.T) {
+	assert.NoError(t, testSchema.ValidateParameters(map[string]interface{}{"ratio": 2}))
+}
+
+func TestValidateSchemaEnum(t *testing.T) {
+	err := testSchema.ValidateRemote(map[string]interface{}{"host": "a", "mode": "slow"})
+	if assert.Error(t, err) {
+		assert.Equal(t, "/mode: must be one of [fast safe], but got 'slow'", err.Error())
+	}
+}
+
+func TestValidateSchemaPattern(t *testing.T) {
+	err := testSchema.ValidateRemote(map[string]interface{}{"host": "a.b/c"})
+	if assert.Error(t, err) {
+		assert.Equal(t, "/host: must match pattern '[a-z.]+'", err.Error())
+	}
+}
+
+func TestValidateSchemaBadPattern(t *testing.T) {
+	schema := []Property{{Name: "a", Type: TypeString, Pattern: "("}}
+	err := ValidateProperties(map[string]interface{}{"a": "b"}, schema)
+	if assert.Error(t, err) {
+		assert.False(t, errors.Is(err, ErrInvalidProperty))
+	}
+}
+
+func TestValidateSchemaAggregated(t *testing.T) {
+	err := testSchema.ValidateRemote(map[string]interface{}{"port": "22", "mode": "slow", "user": "joe"})
+	var v *ValidationError
+	if assert.True(t, errors.As(err, &v)) {
+		assert.Equal(t, []FieldError{
+			{Path: "/host", Message: "missing required property"},
+			{Path: "/mode", Message: "must be one of [fast safe], but got 'slow'"},
+			{Path: "/port", Message: "must be of type integer, but got string"},
+			{Path: "/user", Message: "unknown property"},
+		}, v.Errors)
+		assert.True(t, errors.Is(err, ErrInvalidProperty))
+	}
+}
+
+func TestValidateSchemaNested(t *testing.T) {
+	schema := []Property{
+		{Name: "tags", Type: TypeArray, Items: &Property{Type: TypeObject, Properties: []Property{
+			{Name: "name", Type: TypeString, Required: true},
+			{Name: "a/b", Type: TypeInteger},
+		}}},
+	}
+	err := ValidateProperties(map[string]interface{}{
+		"tags": []interface{}{
+			map[string]interface{}{"name": "one"},
+			map[string]interface{}{"a/b": "x"},
+			"three",
+		},
+	}, schema)
+	var v *ValidationError
+	if assert.True(t, errors.As(err, &v)) {
+		assert.Equal(t, []FieldError{
+			{Path: "/tags/1/a~1b", Message: "must be of type integer, but got string"},
+			{Path: "/tags/1/name", Message: "missing required property"},
+			{Path: "/tags/2", Message: "must be of type object, but got string"},
+		}, v.Errors)
+	}
+}
+
+func TestValidateSchemaNull(t *testing.T) {
+	assert.NoError(t, testSchema.ValidateRemote(map[string]interface{}{"host": "a", "port": nil}))
+	err := testSchema.ValidateRemote(map[string]interface{}{"host": nil})
+	if assert.Error(t, err) {
+		assert.Equal(t, "/host: cannot be null", err.Error())
+	}
+}
+
+func TestApplyDefaults(t *testing.T) {
+	props := map[string]interface{}{"host": "a"}
+	ret := ApplyDefaults(props, testSchema.Remote)
+	assert.Equal(t, map[string]interface{}{"host": "a", "port": float64(22)}, ret)
+	assert.Len(t, props, 1)
+
+	ret = ApplyDefaults(map[string]interface{}{"host": "a", "port": 2222}, testSchema.Remote)
+	assert.Equal(t, 2222, ret["port"])
+}
+
+func TestGetSchema(t *testing.T) {
+	schema, err := GetSchema(context.Background(), schemaRemote{new(MockRemote)})
+	if assert.NoError(t, err) {
+		assert.Equal(t, testSchema, schema)
+	}
+}
+
+func TestGetSchemaUnsupported(t *testing.T) {
+	_, err := GetSchema(context.Background(), new(MockRemote))
+	assert.True(t, errors.Is(err, ErrUnsupported))
+}
+
+func TestSchemaCapability(t *testing.T) {
+	caps, err := GetCapabilities(context.Background(), schemaRemote{new(MockRemote)})
+	if assert.NoError(t, err) {
+		assert.Equal(t, Capabilities{Schema: true}, caps)
+	}
+}
+
+func TestSchemaProto(t *testing.T) {
+	server := &remoteRPCServer{Impl: WithContext(schemaRemote{new(MockRemote)})}
+	res, err := server.GetSchema(context.Background(), nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	remote, err := propertiesFromProto(res.Remote)
+	if assert.NoError(t, err) {
+		assert.Equal(t, testSchema.Remote, remote)
+	}
+	params, err := propertiesFromProto(res.Parameters)
+	if assert.NoError(t, err) {
+		assert.Equal(t, testSchema.Parameters, params)
+	}
+}
+
+func TestSchemaProtoUnsupported(t *testing.T) {
+	server := &remoteRPCServer{Impl: WithContext(new(MockRemote))}
+	_, err := server.GetSchema(context.Background(), nil)
+	assert.True(t, errors.Is(err, ErrUnsupported))
+}
@@ -0,0 +1,456 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"errors"
+	"github.com/hashicorp/go-plugin"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+/*
+ * Optional interface for remotes that can report whether they are able to serve requests, for example by checking
+ * connectivity to a backing service. Loaded plugins that fail their health check are restarted.
+ */
+type HealthRemote interface {
+	Health(ctx context.Context) error
+}
+
+/*
+ * Check the health of the given remote. Remotes that don't implement HealthRemote are always healthy, though a
+ * loaded plugin must still respond.
+ */
+func Health(ctx context.Context, r Remote) error {
+	if h, ok := underlying(r).(HealthRemote); ok {
+		return h.Health(ctx)
+	}
+	return nil
+}
+
+/*
+ * The state of a loaded plugin, as tracked by its supervisor.
+ */
+type PluginStatus struct {
+	Type        string
+	Path        string
+	Version     int
+	Running     bool
+	Restarts    int
+	LastFailure string
+	FailedAt    time.Time
+}
+
+const (
+	defaultHealthInterval  = 10 * time.Second
+	defaultHealthTimeout   = 5 * time.Second
+	defaultHealthThreshold = 3
+)
+
+/*
+ * Delay before retrying a failed restart, doubling after each failure up to the maximum.
+ */
+var (
+	restartBackoff    = 100 * time.Millisecond
+	maxRestartBackoff = 30 * time.Second
+)
+
+/*
+ * Check the health of loaded plugins at the given interval, rather than every 10 seconds. An interval of zero
+ * disables health checks, though plugins that have exited are still restarted when they are next called.
+ */
+func WithHealthInterval(interval time.Duration) Option {
+	return optionFunc(func(o *options) {
+		o.healthInterval = interval
+	})
+}
+
+/*
+ * Fail health checks that take longer than the given timeout, rather than 5 seconds.
+ */
+func WithHealthTimeout(timeout time.Duration) Option {
+	return optionFunc(func(o *options) {
+		o.healthTimeout = timeout
+	})
+}
+
+/*
+ * Restart a plugin only after the given number of consecutive failed health checks, rather than 3. Plugins that have
+ * exited are always restarted immediately.
+ */
+func WithHealthThreshold(failures int) Option {
+	return optionFunc(func(o *options) {
+		o.healthThreshold = failures
+	})
+}
+
+/*
+ * Keeps a plugin running, restarting it whenever it exits or repeatedly fails its health check. Calls are made
+ * through supervisedRemote, which always uses the current plugin process, and are counted so that a plugin that is
+ * busy with calls is never considered unhealthy.
+ */
+type supervisor struct {
+	registry   *Registry
+	remoteType string
+	binary     string
+	impl       Remote
+	active     int32
+
+	mu          sync.Mutex
+	loaded      loadedRemote
+	running     bool
+	restarts    int
+	failures    int
+	lastFailure error
+	failedAt    time.Time
+	nextAttempt time.Time
+	backoff     time.Duration
+	restarting  chan struct{}
+	closed      bool
+	done        chan struct{}
+}
+
+func newSupervisor(registry *Registry, remoteType string, binary string, impl Remote) (*supervisor, error) {
+	loaded, err := registry.loadPlugin(remoteType, binary, impl)
+	if err != nil {
+		return nil, err
+	}
+	s := &supervisor{
+		registry:   registry,
+		remoteType: remoteType,
+		binary:     binary,
+		impl:       impl,
+		loaded:     loaded,
+		running:    true,
+		backoff:    restartBackoff,
+		done:       make(chan struct{}),
+	}
+	if o := registry.currentOptions(); o.healthInterval > 0 {
+		go s.monitor(o.healthInterval, o.healthTimeout, o.healthThreshold)
+	}
+	return s, nil
+}
+
+func (s *supervisor) monitor(interval time.Duration, timeout time.Duration, threshold int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.check(timeout, threshold)
+		}
+	}
+}
+
+/*
+ * Restart the plugin if it has exited, or if it has failed the given number of consecutive health checks. Failures
+ * are not counted while calls are in flight, as a busy plugin may be too slow to respond within the timeout.
+ */
+func (s *supervisor) check(timeout time.Duration, threshold int) {
+	s.mu.Lock()
+	loaded := s.loaded
+	s.mu.Unlock()
+
+	if loaded.c.Exited() {
+		s.restart(loaded.c, errors.New("plugin exited"))
+		return
+	}
+	busy := atomic.LoadInt32(&s.active) > 0
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	err := Health(ctx, loaded.r)
+	cancel()
+	if err != nil && (busy || atomic.LoadInt32(&s.active) > 0) {
+		return
+	}
+
+	s.mu.Lock()
+	if s.loaded.c != loaded.c {
+		s.mu.Unlock()
+		return
+	}
+	if err == nil {
+		s.failures = 0
+	} else {
+		s.failures++
+	}
+	failed := s.failures >= threshold
+	s.mu.Unlock()
+	if failed {
+		s.restart(loaded.c, err)
+	}
+}
+
+/*
+ * Replace the given failed plugin process with a new one. Nothing is done if the process has already been replaced,
+ * or if a previous restart failed and its backoff has not yet expired. The new process is launched without holding
+ * the lock, so that status queries are never blocked by a slow plugin; concurrent callers wait for the restart in
+ * progress instead of launching their own.
+ */
+func (s *supervisor) restart(failed *plugin.Client, reason error) {
+	s.mu.Lock()
+	if s.closed || s.loaded.c != failed {
+		s.mu.Unlock()
+		return
+	}
+	if restarting := s.restarting; restarting != nil {
+		s.mu.Unlock()
+		<-restarting
+		return
+	}
+	now := time.Now()
+	if s.running {
+		s.running = false
+		s.lastFailure = reason
+		s.failedAt = now
+	}
+	if now.Before(s.nextAttempt) {
+		s.mu.Unlock()
+		return
+	}
+	restarting := make(chan struct{})
+	s.restarting = restarting
+	s.mu.Unlock()
+
+	failed.Kill()
+	loaded, err := s.registry.loadPlugin(s.remoteType, s.binary, s.impl)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.restarting = nil
+	close(restarting)
+	if s.closed {
+		if err == nil {
+			loaded.c.Kill()
+		}
+		return
+	}
+	if err != nil {
+		s.lastFailure = err
+		s.failedAt = now
+		s.nextAttempt = now.Add(s.backoff)
+		s.backoff *= 2
+		if s.backoff > maxRestartBackoff {
+			s.backoff = maxRestartBackoff
+		}
+		return
+	}
+
+	s.loaded = loaded
+	s.running = true
+	s.restarts++
+	s.failures = 0
+	s.backoff = restartBackoff
+	s.nextAttempt = time.Time{}
+}
+
+/*
+ * Returns the client for the current plugin process, first restarting it if it has exited.
+ */
+func (s *supervisor) current() *remoteRPCClient {
+	s.mu.Lock()
+	loaded := s.loaded
+	s.mu.Unlock()
+	if loaded.c.Exited() {
+		s.restart(loaded.c, errors.New("plugin exited"))
+		s.mu.Lock()
+		loaded = s.loaded
+		s.mu.Unlock()
+	}
+	return underlying(loaded.r).(*remoteRPCClient)
+}
+
+/*
+ * Returns the current client, counting a call as in flight until release() is called.
+ */
+func (s *supervisor) acquire() *remoteRPCClient {
+	atomic.AddInt32(&s.active, 1)
+	return s.current()
+}
+
+func (s *supervisor) release() {
+	atomic.AddInt32(&s.active, -1)
+}
+
+func (s *supervisor) status() PluginStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := PluginStatus{
+		Type:     s.remoteType,
+		Path:     s.binary,
+		Version:  s.loaded.version,
+		Running:  s.running && !s.loaded.c.Exited(),
+		Restarts: s.restarts,
+		FailedAt: s.failedAt,
+	}
+	if s.lastFailure != nil {
+		status.LastFailure = s.lastFailure.Error()
+	}
+	return status
+}
+
+func (s *supervisor) version() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loaded.version
+}
+
+/*
+ * Stop supervising the plugin and kill it. A restart in progress kills its new process once it has launched.
+ */
+func (s *supervisor) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+		s.loaded.c.Kill()
+	}
+}
+
+/*
+ * A remote whose calls are made to the current process of a supervised plugin. It implements every optional
+ * interface that plugin clients do, so that optional interfaces obtained through it, such as by Operations(), keep
+ * working across restarts.
+ */
+type supervisedRemote struct {
+	s *supervisor
+}
+
+func (r supervisedRemote) Type(ctx context.Context) (string, error) {
+	defer r.s.release()
+	return r.s.acquire().Type(ctx)
+}
+
+func (r supervisedRemote) FromURL(ctx context.Context, url string, properties map[string]string) (map[string]interface{}, error) {
+	defer r.s.release()
+	return r.s.acquire().FromURL(ctx, url, properties)
+}
+
+func (r supervisedRemote) ToURL(ctx context.Context, properties map[string]interface{}) (string, map[string]string, error) {
+	defer r.s.release()
+	return r.s.acquire().ToURL(ctx, properties)
+}
+
+func (r supervisedRemote) GetParameters(ctx context.Context, properties map[string]interface{}) (map[string]interface{}, error) {
+	defer r.s.release()
+	return r.s.acquire().GetParameters(ctx, properties)
+}
+
+func (r supervisedRemote) ValidateRemote(ctx context.Context, properties map[string]interface{}) error {
+	defer r.s.release()
+	return r.s.acquire().ValidateRemote(ctx, properties)
+}
+
+func (r supervisedRemote) ValidateParameters(ctx context.Context, parameters map[string]interface{}) error {
+	defer r.s.release()
+	return r.s.acquire().ValidateParameters(ctx, parameters)
+}
+
+func (r supervisedRemote) ListCommits(ctx context.Context, properties map[string]interface{}, parameters map[string]interface{}, tags []Tag) ([]Commit, error) {
+	defer r.s.release()
+	return r.s.acquire().ListCommits(ctx, properties, parameters, tags)
+}
+
+func (r supervisedRemote) ListCommitsPage(ctx context.Context, properties map[string]interface{}, parameters map[string]interface{}, tags []Tag, page PageRequest) (CommitPage, error) {
+	defer r.s.release()
+	return r.s.acquire().ListCommitsPage(ctx, properties, parameters, tags, page)
+}
+
+func (r supervisedRemote) GetCommit(ctx context.Context, properties map[string]interface{}, parameters map[string]interface{}, commitId string) (*Commit, error) {
+	defer r.s.release()
+	return r.s.acquire().GetCommit(ctx, properties, parameters, commitId)
+}
+
+/*
+ * The call is counted as in flight until the iterator is closed.
+ */
+func (r supervisedRemote) IterateCommits(ctx context.Context, properties map[string]interface{}, parameters map[string]interface{}, tags []Tag) (CommitIterator, error) {
+	it, err := r.s.acquire().IterateCommits(ctx, properties, parameters, tags)
+	if err != nil {
+		r.s.release()
+		return nil, err
+	}
+	return &supervisedIterator{CommitIterator: it, s: r.s}, nil
+}
+
+func (r supervisedRemote) StartOperation(ctx context.Context, operation Operation) (map[string]interface{}, error) {
+	defer r.s.release()
+	return r.s.acquire().StartOperation(ctx, operation)
+}
+
+func (r supervisedRemote) PushMetadata(ctx context.Context, operation Operation, commit Commit, isUpdate bool) error {
+	defer r.s.release()
+	return r.s.acquire().PushMetadata(ctx, operation, commit, isUpdate)
+}
+
+func (r supervisedRemote) SyncVolume(ctx context.Context, operation Operation, volume Volume) error {
+	defer r.s.release()
+	return r.s.acquire().SyncVolume(ctx, operation, volume)
+}
+
+func (r supervisedRemote) EndOperation(ctx context.Context, operation Operation) error {
+	defer r.s.release()
+	return r.s.acquire().EndOperation(ctx, operation)
+}
+
+func (r supervisedRemote) FailOperation(ctx context.Context, operation Operation, reason string) error {
+	defer r.s.release()
+	return r.s.acquire().FailOperation(ctx, operation, reason)
+}
+
+func (r supervisedRemote) UploadVolume(ctx context.Context, operation Operation, volume string, reader io.Reader) error {
+	defer r.s.release()
+	return r.s.acquire().UploadVolume(ctx, operation, volume, reader)
+}
+
+func (r supervisedRemote) UploadVolumeAt(ctx context.Context, operation Operation, volume string, offset int64, reader io.Reader) (int64, error) {
+	defer r.s.release()
+	return r.s.acquire().UploadVolumeAt(ctx, operation, volume, offset, reader)
+}
+
+func (r supervisedRemote) DownloadVolume(ctx context.Context, operation Operation, volume string, w io.Writer) error {
+	defer r.s.release()
+	return r.s.acquire().DownloadVolume(ctx, operation, volume, w)
+}
+
+func (r supervisedRemote) DownloadVolumeAt(ctx context.Context, operation Operation, volume string, offset int64, w io.Writer) error {
+	defer r.s.release()
+	return r.s.acquire().DownloadVolumeAt(ctx, operation, volume, offset, w)
+}
+
+func (r supervisedRemote) VolumeOffset(ctx context.Context, operation Operation, volume string) (int64, error) {
+	defer r.s.release()
+	return r.s.acquire().VolumeOffset(ctx, operation, volume)
+}
+
+func (r supervisedRemote) Schema(ctx context.Context) (Schema, error) {
+	defer r.s.release()
+	return r.s.acquire().Schema(ctx)
+}
+
+func (r supervisedRemote) Health(ctx context.Context) error {
+	defer r.s.release()
+	return r.s.acquire().Health(ctx)
+}
+
+func (r supervisedRemote) capabilities(ctx context.Context) (Capabilities, error) {
+	defer r.s.release()
+	return r.s.acquire().capabilities(ctx)
+}
+
+type supervisedIterator struct {
+	CommitIterator
+	s    *supervisor
+	once sync.Once
+}
+
+func (i *supervisedIterator) Close() error {
+	i.once.Do(i.s.release)
+	return i.CommitIterator.Close()
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func killPlugin(reg *Registry, remoteType string) {
+	s := reg.getLoaded(remoteType)
+	s.mu.Lock()
+	c := s.loaded.c
+	s.mu.Unlock()
+	c.Kill()
+}
+
+func TestSupervisorRestartOnCall(t *testing.T) {
+	reg := NewRegistry(WithHealthInterval(0))
+	defer reg.Clear()
+	r, err := reg.Load("echo", "../build")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	killPlugin(reg, "echo")
+	status, _ := reg.Status("echo")
+	assert.False(t, status.Running)
+
+	typ, err := r.Type()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "echo", typ)
+	}
+	status, ok := reg.Status("echo")
+	if assert.True(t, ok) {
+		assert.True(t, status.Running)
+		assert.Equal(t, 1, status.Restarts)
+		assert.Equal(t, "plugin exited", status.LastFailure)
+		assert.False(t, status.FailedAt.IsZero())
+		assert.Equal(t, ProtocolVersion, status.Version)
+	}
+}
+
+func TestSupervisorOptionalInterfaces(t *testing.T) {
+	reg := NewRegistry(WithHealthInterval(0))
+	defer reg.Clear()
+	r, err := reg.Load("echo", "../build")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	o, ok := Operations(r)
+	if !assert.True(t, ok) {
+		return
+	}
+	killPlugin(reg, "echo")
+	_, err = o.StartOperation(context.Background(), Operation{Id: "op"})
+	assert.NoError(t, err)
+	status, _ := reg.Status("echo")
+	assert.Equal(t, 1, status.Restarts)
+
+	killPlugin(reg, "echo")
+	_, err = GetCapabilities(context.Background(), r)
+	assert.NoError(t, err)
+	status, _ = reg.Status("echo")
+	assert.Equal(t, 2, status.Restarts)
+}
+
+func TestSupervisorHealthThreshold(t *testing.T) {
+	reg := NewRegistry(WithHealthInterval(0))
+	defer reg.Clear()
+	_, err := reg.Load("echo", "../build")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	s := reg.getLoaded("echo")
+	s.check(time.Nanosecond, 3)
+	s.check(time.Nanosecond, 3)
+	assert.Equal(t, 0, s.status().Restarts)
+	s.check(time.Second, 3)
+	s.check(time.Nanosecond, 3)
+	s.check(time.Nanosecond, 3)
+	assert.Equal(t, 0, s.status().Restarts)
+	s.check(time.Nanosecond, 3)
+	status := s.status()
+	assert.Equal(t, 1, status.Restarts)
+	assert.True(t, status.Running)
+	assert.Equal(t, context.DeadlineExceeded.Error(), status.LastFailure)
+}
+
+func TestSupervisorHealthBusy(t *testing.T) {
+	reg := NewRegistry(WithHealthInterval(0))
+	defer reg.Clear()
+	_, err := reg.Load("echo", "../build")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	s := reg.getLoaded("echo")
+	s.acquire()
+	for i := 0; i < 3; i++ {
+		s.check(time.Nanosecond, 1)
+	}
+	s.release()
+	assert.Equal(t, 0, s.status().Restarts)
+	s.check(time.Nanosecond, 1)
+	assert.Equal(t, 1, s.status().Restarts)
+}
+
+func TestSupervisorHealthCheck(t *testing.T) {
+	reg := NewRegistry(WithHealthInterval(10 * time.Millisecond))
+	defer reg.Clear()
+	_, err := reg.Load("echo", "../build")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	killPlugin(reg, "echo")
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if status, _ := reg.Status("echo"); status.Restarts == 1 && status.Running {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("plugin was not restarted")
+}
+
+func TestSupervisorRestartFailure(t *testing.T) {
+	defer func(backoff time.Duration) { restartBackoff = backoff }(restartBackoff)
+	restartBackoff = time.Millisecond
+
+	dir, binary := copyEcho(t)
+	defer os.RemoveAll(dir)
+	data, err := ioutil.ReadFile(binary)
+	if !assert.NoError(t, err) {
+		return
+	}
+	reg := NewRegistry(WithHealthInterval(0))
+	defer reg.Clear()
+	r, err := reg.Load("echo", dir)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	os.Remove(binary)
+	killPlugin(reg, "echo")
+	_, err = r.Type()
+	assert.Error(t, err)
+	status, _ := reg.Status("echo")
+	assert.False(t, status.Running)
+	assert.Equal(t, 0, status.Restarts)
+	assert.NotEqual(t, "plugin exited", status.LastFailure)
+
+	if !assert.NoError(t, ioutil.WriteFile(binary, data, 0755)) {
+		return
+	}
+	time.Sleep(10 * time.Millisecond)
+	_, err = r.Type()
+	assert.NoError(t, err)
+	status, _ = reg.Status("echo")
+	assert.True(t, status.Running)
+	assert.Equal(t, 1, status.Restarts)
+}
+
+func TestSupervisorUnload(t *testing.T) {
+	reg := NewRegistry(WithHealthInterval(time.Millisecond))
+	_, err := reg.Load("echo", "../build")
+	if !assert.NoError(t, err) {
+		return
+	}
+	s := reg.getLoaded("echo")
+	reg.Unload("echo")
+	_, ok := reg.Status("echo")
+	assert.False(t, ok)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, 0, s.status().Restarts)
+}
+
+type unhealthyRemote struct {
+	*MockRemote
+}
+
+func (r unhealthyRemote) Health(ctx context.Context) error {
+	return Errorf(ErrUnavailable, "backend unreachable")
+}
+
+func TestHealth(t *testing.T) {
+	assert.NoError(t, Health(context.Background(), new(MockRemote)))
+	err := Health(context.Background(), unhealthyRemote{new(MockRemote)})
+	assert.True(t, errors.Is(err, ErrUnavailable))
+}
+
+func TestServerHealth(t *testing.T) {
+	server := &remoteRPCServer{Impl: WithContext(new(MockRemote))}
+	_, err := server.Health(context.Background(), nil)
+	assert.NoError(t, err)
+
+	server = &remoteRPCServer{Impl: WithContext(unhealthyRemote{new(MockRemote)})}
+	_, err = server.Health(context.Background(), nil)
+	assert.True(t, errors.Is(err, ErrUnavailable))
+}
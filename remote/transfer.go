@@ -0,0 +1,163 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"context"
+	proto "github.com/titan-data/remote-sdk-go/internal/proto"
+	"hash/crc32"
+	"io"
+)
+
+/*
+ * Optional interface for remotes that can transfer raw volume contents. Data is streamed in fixed size chunks, each
+ * carrying a checksum that is verified on receipt, so that volumes of any size can be moved without buffering them
+ * in memory. Uploads and downloads are named from the perspective of the host: an upload sends data to the remote.
+ */
+type VolumeTransferRemote interface {
+
+	/*
+	 * Consume the contents of the given volume from the reader and store them in the remote.
+	 */
+	UploadVolume(ctx context.Context, operation Operation, volume string, r io.Reader) error
+
+	/*
+	 * Write the contents of the given volume from the remote into the writer.
+	 */
+	DownloadVolume(ctx context.Context, operation Operation, volume string, w io.Writer) error
+}
+
+/*
+ * Optional interface for remotes that can resume an interrupted transfer. The remote tracks how much of each volume
+ * has been durably committed, and accepts uploads that start at any committed offset. Downloads can start at any
+ * offset within the volume.
+ */
+type ResumableTransferRemote interface {
+	VolumeTransferRemote
+
+	/*
+	 * Returns the number of bytes of the given volume that have been durably committed to the remote.
+	 */
+	VolumeOffset(ctx context.Context, operation Operation, volume string) (int64, error)
+
+	/*
+	 * Consume data from the reader and store it in the volume starting at the given offset, which is never greater
+	 * than the committed offset. Returns the committed offset once all data has been consumed.
+	 */
+	UploadVolumeAt(ctx context.Context, operation Operation, volume string, offset int64, r io.Reader) (int64, error)
+
+	/*
+	 * Write the contents of the given volume into the writer, starting at the given offset.
+	 */
+	DownloadVolumeAt(ctx context.Context, operation Operation, volume string, offset int64, w io.Writer) error
+}
+
+/*
+ * Returns the volume transfer interface for the given remote, or false if the remote does not support transfers.
+ */
+func Transfers(r Remote) (VolumeTransferRemote, bool) {
+	t, ok := underlying(r).(VolumeTransferRemote)
+	return t, ok
+}
+
+/*
+ * Maximum amount of data carried in a single chunk, well under the default gRPC message limit.
+ */
+const chunkSize = 64 * 1024
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+func checksum(data []byte) uint32 {
+	return crc32.Checksum(data, crcTable)
+}
+
+func verifyChunk(chunk *proto.VolumeChunk, offset int64) error {
+	if chunk.Offset != offset {
+		return Errorf(ErrDataLoss, "unexpected chunk at offset %d, expected offset %d", chunk.Offset, offset)
+	}
+	if checksum(chunk.Data) != chunk.Checksum {
+		return Errorf(ErrDataLoss, "checksum mismatch for chunk at offset %d", chunk.Offset)
+	}
+	return nil
+}
+
+/*
+ * Reads data from a sequence of chunks, verifying the offset and checksum of each chunk as it is received.
+ */
+type chunkReader struct {
+	recv   func() (*proto.VolumeChunk, error)
+	buf    []byte
+	offset int64
+	err    error
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	for len(c.buf) == 0 {
+		if c.err != nil {
+			return 0, c.err
+		}
+		chunk, err := c.recv()
+		if err == nil {
+			err = verifyChunk(chunk, c.offset)
+		}
+		if err != nil {
+			c.err = err
+			return 0, err
+		}
+		c.buf = chunk.Data
+	}
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	c.offset += int64(n)
+	return n, nil
+}
+
+/*
+ * Writes data as a sequence of chunks. The header, if present, is sent with the first chunk only. Close() must be
+ * called to ensure the header is sent even if no data was written.
+ */
+type chunkWriter struct {
+	send   func(*proto.VolumeChunk) error
+	header *proto.VolumeChunk
+	offset int64
+}
+
+func (c *chunkWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := len(p)
+		if n > chunkSize {
+			n = chunkSize
+		}
+		if err := c.sendChunk(p[:n]); err != nil {
+			return written, err
+		}
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
+
+func (c *chunkWriter) Close() error {
+	if c.header != nil {
+		return c.sendChunk(nil)
+	}
+	return nil
+}
+
+func (c *chunkWriter) sendChunk(data []byte) error {
+	chunk := c.header
+	c.header = nil
+	if chunk == nil {
+		chunk = &proto.VolumeChunk{}
+	}
+	chunk.Offset = c.offset
+	chunk.Data = data
+	chunk.Checksum = checksum(data)
+	if err := c.send(chunk); err != nil {
+		return err
+	}
+	c.offset += int64(len(data))
+	return nil
+}
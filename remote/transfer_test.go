@@ -0,0 +1,73 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	proto "github.com/titan-data/remote-sdk-go/internal/proto"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+)
+
+func transferChunks(t *testing.T, data []byte) []*proto.VolumeChunk {
+	chunks := []*proto.VolumeChunk{}
+	w := &chunkWriter{send: func(c *proto.VolumeChunk) error {
+		chunks = append(chunks, c)
+		return nil
+	}, header: &proto.VolumeChunk{Volume: "vol"}}
+	_, err := w.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	return chunks
+}
+
+func chunkSource(chunks []*proto.VolumeChunk) func() (*proto.VolumeChunk, error) {
+	return func() (*proto.VolumeChunk, error) {
+		if len(chunks) == 0 {
+			return nil, io.EOF
+		}
+		c := chunks[0]
+		chunks = chunks[1:]
+		return c, nil
+	}
+}
+
+func TestChunkRoundTrip(t *testing.T) {
+	data := make([]byte, 3*chunkSize+7)
+	rand.Read(data)
+	chunks := transferChunks(t, data)
+	assert.Len(t, chunks, 4)
+	assert.Equal(t, "vol", chunks[0].Volume)
+	assert.Empty(t, chunks[1].Volume)
+
+	out, err := ioutil.ReadAll(&chunkReader{recv: chunkSource(chunks)})
+	if assert.NoError(t, err) {
+		assert.True(t, bytes.Equal(data, out))
+	}
+}
+
+func TestChunkEmpty(t *testing.T) {
+	chunks := transferChunks(t, []byte{})
+	if assert.Len(t, chunks, 1) {
+		assert.Equal(t, "vol", chunks[0].Volume)
+		assert.Empty(t, chunks[0].Data)
+	}
+}
+
+func TestChunkBadChecksum(t *testing.T) {
+	chunks := transferChunks(t, make([]byte, chunkSize+1))
+	chunks[1].Data[0] ^= 0xff
+	_, err := ioutil.ReadAll(&chunkReader{recv: chunkSource(chunks)})
+	assert.Error(t, err)
+}
+
+func TestChunkBadOffset(t *testing.T) {
+	chunks := transferChunks(t, make([]byte, 2*chunkSize))
+	chunks[0], chunks[1] = chunks[1], chunks[0]
+	_, err := ioutil.ReadAll(&chunkReader{recv: chunkSource(chunks)})
+	assert.Error(t, err)
+}
@@ -13,12 +13,14 @@ import (
 
 /*
  * Wrap remote URL parsing in an easier-to use function that will handle converting to the intermediate URL format,
- * processing any query parameters (for tags) and fragment (for commit IDs).
+ * processing any query parameters (for tags) and fragment (for commit IDs). The scheme is normally the remote type,
+ * but may be any scheme listed in a remote's manifest, in which case the remote type is returned. If the remote's
+ * plugin fails to load, the reason is returned rather than reporting an unknown provider.
  */
 func ParseURL(input string, properties map[string]string) (string, map[string]interface{}, []string, string, error) {
 	u, err := url.Parse(input)
 	if err != nil {
-		return "", nil, nil, "", err
+		return "", nil, nil, "", fmt.Errorf("%w: %v", ErrInvalidURL, err)
 	}
 
 	var provider string
@@ -28,16 +30,18 @@ func ParseURL(input string, properties map[string]string) (string, map[string]in
 		provider = u.Path
 	}
 
-	var r = Get(provider)
-	if r == nil {
-		return "", nil, nil, "", errors.New(fmt.Sprintf("unknown remote provider '%s'", provider))
+	remoteType, r, err := defaultRegistry.lookupScheme(provider)
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrInvalidType) {
+		return "", nil, nil, "", Errorf(ErrInvalidURL, "unknown remote provider '%s'", provider)
+	} else if err != nil {
+		return "", nil, nil, "", fmt.Errorf("failed to load remote provider '%s': %w", provider, err)
 	}
 
 	commit := u.Fragment
 	tags := []string{}
 	for k := range u.Query() {
 		if k != "tag" {
-			return "", nil, nil, "", errors.New(fmt.Sprintf("invalid query parameter '%s'", k))
+			return "", nil, nil, "", Errorf(ErrInvalidURL, "invalid query parameter '%s'", k)
 		}
 	}
 	if u.Query()["tag"] != nil {
@@ -51,7 +55,7 @@ func ParseURL(input string, properties map[string]string) (string, map[string]in
 		return "", nil, nil, "", err
 	}
 
-	return provider, props, tags, commit, nil
+	return remoteType, props, tags, commit, nil
 }
 
 func contains(arr []string, search string) bool {
@@ -84,34 +88,42 @@ func getTimestamp(raw interface{}) time.Time {
 	}
 }
 
+/*
+ * Returns the time of a commit from its "timestamp" property, in RFC 3339 format. Missing or malformed timestamps are
+ * treated as the epoch, as they are by SortCommits().
+ */
+func CommitTimestamp(commit Commit) time.Time {
+	return getTimestamp(commit.Properties["timestamp"])
+}
+
 /**
  * Sorts a list of commits in reverse descending order, based on timestamp.
  */
 func SortCommits(commits []Commit) {
 	sort.Slice(commits, func(i, j int) bool {
-		t1 := getTimestamp(commits[i].Properties["timestamp"])
-		t2 := getTimestamp(commits[j].Properties["timestamp"])
-		return t1.After(t2)
+		return CommitTimestamp(commits[i]).After(CommitTimestamp(commits[j]))
 	})
 }
 
 /*
- * Validate a set of properties (as with remotes and parameters) for required and optional fields.
+ * Validate a set of properties (as with remotes and parameters) for required and optional fields. All missing and
+ * unknown properties are reported together as a *ValidationError.
  */
 func ValidateFields(properties map[string]interface{}, required []string, optional []string) error {
+	v := &ValidationError{}
 	for _, p := range required {
 		if _, ok := properties[p]; !ok {
-			return fmt.Errorf("missing required property '%s'", p)
+			v.Add(JoinPath("", p), "missing required property")
 		}
 	}
 
 	for p := range properties {
 		if !contains(required, p) && !contains(optional, p) {
-			return fmt.Errorf("invalid property '%s'", p)
+			v.Add(JoinPath("", p), "unknown property")
 		}
 	}
 
-	return nil
+	return v.Err()
 }
 
 /*
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"os"
 	"testing"
 )
 
@@ -29,7 +30,7 @@ func TestProvider(t *testing.T) {
 func TestBadProvider(t *testing.T) {
 	_ = registerDefaultRemote()
 	_, _, _, _, err := ParseURL("notmock", map[string]string{})
-	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidURL))
 }
 
 func TestBadURL(t *testing.T) {
@@ -176,11 +177,32 @@ func TestValidateFields(t *testing.T) {
 }
 
 func TestValidateMissingRequired(t *testing.T) {
-	assert.Error(t, ValidateFields(map[string]interface{}{}, []string{"a"}, []string{}))
+	err := ValidateFields(map[string]interface{}{}, []string{"a"}, []string{})
+	assert.True(t, errors.Is(err, ErrInvalidProperty))
 }
 
 func TestValidateInvalid(t *testing.T) {
-	assert.Error(t, ValidateFields(map[string]interface{}{"c": "C"}, []string{}, []string{"b"}))
+	err := ValidateFields(map[string]interface{}{"c": "C"}, []string{}, []string{"b"})
+	assert.True(t, errors.Is(err, ErrInvalidProperty))
+}
+
+func TestValidateAggregated(t *testing.T) {
+	err := ValidateFields(map[string]interface{}{"c": "C", "d": "D"}, []string{"a"}, []string{"b"})
+	var v *ValidationError
+	if assert.True(t, errors.As(err, &v)) {
+		assert.Equal(t, []FieldError{
+			{Path: "/a", Message: "missing required property"},
+			{Path: "/c", Message: "unknown property"},
+			{Path: "/d", Message: "unknown property"},
+		}, v.Errors)
+	}
+}
+
+func TestCommitTimestamp(t *testing.T) {
+	c := Commit{Properties: map[string]interface{}{"timestamp": "2019-09-20T13:45:36Z"}}
+	assert.Equal(t, int64(1568987136), CommitTimestamp(c).Unix())
+	assert.Equal(t, int64(0), CommitTimestamp(Commit{Properties: map[string]interface{}{"timestamp": "bad"}}).Unix())
+	assert.Equal(t, int64(0), CommitTimestamp(Commit{}).Unix())
 }
 
 func TestSortDescending(t *testing.T) {
@@ -257,3 +279,70 @@ func TestSortBadTimestamp(t *testing.T) {
 	assert.Equal(t, "one", commits[2].Id)
 	assert.Equal(t, "four", commits[3].Id)
 }
+
+func TestParseURLPathTraversal(t *testing.T) {
+	Clear()
+	Configure(WithSearchPath("/usr/bin"))
+	defer Configure(WithSearchPath())
+
+	_, _, _, _, err := ParseURL("../../../../../../usr/bin/id", map[string]string{})
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, ErrInvalidURL))
+		assert.Equal(t, "unknown remote provider '../../../../../../usr/bin/id'", err.Error())
+	}
+	assert.Empty(t, defaultRegistry.List())
+}
+
+func TestParseURLLoadFailure(t *testing.T) {
+	dir := tempPluginDir(t, map[string]os.FileMode{"titan-remote-broken": 0755})
+	defer os.RemoveAll(dir)
+	Clear()
+	Configure(WithSearchPath(dir))
+	defer Configure(WithSearchPath())
+
+	_, _, _, _, err := ParseURL("broken://foo", map[string]string{})
+	if assert.Error(t, err) {
+		assert.False(t, errors.Is(err, ErrInvalidURL))
+		assert.Contains(t, err.Error(), "failed to load remote provider 'broken'")
+	}
+}
+
+type schemeRemote struct {
+	*MockRemote
+}
+
+func (r schemeRemote) Manifest() Manifest {
+	return Manifest{Schemes: []string{"mock+ssh"}}
+}
+
+func TestParseURLScheme(t *testing.T) {
+	Clear()
+	r := new(MockRemote)
+	r.On("Type").Return("mock")
+	r.On("FromURL", "mock+ssh://host", mock.Anything).Return(map[string]interface{}{"host": "host"}, nil)
+	Register(schemeRemote{r})
+
+	provider, props, _, _, err := ParseURL("mock+ssh://host", map[string]string{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "mock", provider)
+		assert.Equal(t, map[string]interface{}{"host": "host"}, props)
+	}
+	_, _, _, _, err = ParseURL("other://host", map[string]string{})
+	assert.True(t, errors.Is(err, ErrInvalidURL))
+}
+
+func TestParseURLPluginScheme(t *testing.T) {
+	dir, binary := copyEcho(t)
+	defer os.RemoveAll(dir)
+	writeManifest(t, binary, Manifest{Type: "echo", Schemes: []string{"echo", "ping"}})
+	Clear()
+	Configure(WithSearchPath(dir))
+	defer Configure(WithSearchPath())
+	defer Clear()
+
+	provider, props, _, _, err := ParseURL("ping://host", map[string]string{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "echo", provider)
+		assert.Equal(t, "ping://host", props["url"])
+	}
+}
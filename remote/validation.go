@@ -0,0 +1,80 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/*
+ * A single problem found while validating properties. The path is a JSON pointer (RFC 6901) to the offending value,
+ * such as "/tags/0/name", and is empty for problems with the properties as a whole.
+ */
+type FieldError struct {
+	Path    string
+	Message string
+}
+
+func (e FieldError) String() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+/*
+ * Collects every problem found while validating a set of properties, so that they can all be reported at once rather
+ * than one at a time. Validation errors are of kind ErrInvalidProperty, and their individual field errors are
+ * preserved across the plugin boundary.
+ */
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].String()
+	}
+	messages := make([]string, len(e.Errors))
+	for i, f := range e.Errors {
+		messages[i] = f.String()
+	}
+	return fmt.Sprintf("%d validation errors: %s", len(e.Errors), strings.Join(messages, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidProperty
+}
+
+/*
+ * Record a problem with the value at the given path.
+ */
+func (e *ValidationError) Add(path string, format string, args ...interface{}) {
+	e.Errors = append(e.Errors, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+/*
+ * Returns nil if no problems have been recorded, and otherwise the validation error with its field errors sorted by
+ * path. This should be used to return the result of validation, so that callers can compare the error to nil.
+ */
+func (e *ValidationError) Err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	sort.SliceStable(e.Errors, func(i, j int) bool {
+		return e.Errors[i].Path < e.Errors[j].Path
+	})
+	return e
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+/*
+ * Append a map key or list index to a JSON pointer, escaping it as needed.
+ */
+func JoinPath(path string, key interface{}) string {
+	return path + "/" + pointerEscaper.Replace(fmt.Sprint(key))
+}
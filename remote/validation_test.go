@@ -0,0 +1,77 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidationErrorNone(t *testing.T) {
+	v := &ValidationError{}
+	assert.NoError(t, v.Err())
+}
+
+func TestValidationErrorSingle(t *testing.T) {
+	v := &ValidationError{}
+	v.Add("/a", "bad value %d", 1)
+	err := v.Err()
+	if assert.Error(t, err) {
+		assert.Equal(t, "/a: bad value 1", err.Error())
+		assert.True(t, errors.Is(err, ErrInvalidProperty))
+	}
+}
+
+func TestValidationErrorMultiple(t *testing.T) {
+	v := &ValidationError{}
+	v.Add("/b", "second")
+	v.Add("", "whole")
+	v.Add("/a", "first")
+	err := v.Err()
+	if assert.Error(t, err) {
+		assert.Equal(t, "3 validation errors: whole; /a: first; /b: second", err.Error())
+	}
+}
+
+func TestJoinPath(t *testing.T) {
+	assert.Equal(t, "/a", JoinPath("", "a"))
+	assert.Equal(t, "/a/0", JoinPath("/a", 0))
+	assert.Equal(t, "/a~1b/c~0d", JoinPath(JoinPath("", "a/b"), "c~d"))
+}
+
+func TestValidationErrorProto(t *testing.T) {
+	v := &ValidationError{}
+	v.Add("/a", "first")
+	v.Add("/b", "second")
+	err := decodeError(encodeError(fmt.Errorf("validation failed: %w", v.Err())))
+	var decoded *ValidationError
+	if assert.True(t, errors.As(err, &decoded)) {
+		assert.Equal(t, v.Errors, decoded.Errors)
+		assert.True(t, errors.Is(err, ErrInvalidProperty))
+		assert.Equal(t, "validation failed: 2 validation errors: /a: first; /b: second", err.Error())
+	}
+
+	err = decodeError(encodeError(v.Err()))
+	assert.Equal(t, v, err)
+}
+
+func TestValidationErrorRedacted(t *testing.T) {
+	r := NewRedactor("password")
+	r.Observe(map[string]interface{}{"password": "secret"})
+	v := &ValidationError{}
+	v.Add("/password", "'secret' is too short")
+	err := r.RedactError(v.Err())
+	var redacted *ValidationError
+	if assert.True(t, errors.As(err, &redacted)) {
+		assert.Equal(t, []FieldError{{Path: "/password", Message: "'****' is too short"}}, redacted.Errors)
+	}
+
+	err = r.RedactError(fmt.Errorf("invalid remote: %w", v.Err()))
+	if assert.True(t, errors.As(err, &redacted)) {
+		assert.Equal(t, "invalid remote: /password: '****' is too short", err.Error())
+		assert.Equal(t, []FieldError{{Path: "/password", Message: "'****' is too short"}}, redacted.Errors)
+	}
+}
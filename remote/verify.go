@@ -0,0 +1,135 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-plugin"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+/*
+ * Returned by Load() when a plugin binary doesn't match the checksum in its manifest, or isn't signed by a trusted
+ * key. The plugin is never executed.
+ */
+var ErrUntrustedPlugin = errors.New("untrusted plugin")
+
+const checksumPrefix = "sha256:"
+
+/*
+ * Trust plugins signed by the given ed25519 public keys. Once any keys are trusted, plugins without a valid signature
+ * from one of them are refused.
+ */
+func WithTrustedKeys(keys ...ed25519.PublicKey) Option {
+	return optionFunc(func(o *options) {
+		o.trustedKeys = append(o.trustedKeys, keys...)
+	})
+}
+
+/*
+ * Returns the path of the signature for the given plugin binary.
+ */
+func SignaturePath(binary string) string {
+	return binary + ".sig"
+}
+
+/*
+ * Returns the checksum of a plugin binary, in the form used by manifests.
+ */
+func PluginChecksum(binary string) (string, error) {
+	digest, err := fileDigest(binary)
+	if err != nil {
+		return "", err
+	}
+	return checksumPrefix + hex.EncodeToString(digest), nil
+}
+
+/*
+ * Sign a plugin binary with the given key, writing the signature alongside the binary. The signature is of the
+ * SHA-256 digest of the binary, stored base64-encoded.
+ */
+func SignPlugin(binary string, key ed25519.PrivateKey) error {
+	digest, err := fileDigest(binary)
+	if err != nil {
+		return err
+	}
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(key, digest))
+	return ioutil.WriteFile(SignaturePath(binary), []byte(sig+"\n"), 0644)
+}
+
+/*
+ * Verify a plugin binary before it is executed. If the plugin has a manifest with a checksum, the binary must match
+ * it, and if any keys are trusted, the binary must be signed by one of them. Returns the configuration that has
+ * go-plugin check the checksum again as the plugin is launched, or nil if there is nothing to verify.
+ */
+func verifyPlugin(binary string, manifest *Manifest, trustedKeys []ed25519.PublicKey) (*plugin.SecureConfig, error) {
+	if (manifest == nil || manifest.Checksum == "") && len(trustedKeys) == 0 {
+		return nil, nil
+	}
+
+	digest, err := fileDigest(binary)
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest != nil && manifest.Checksum != "" {
+		if !strings.HasPrefix(manifest.Checksum, checksumPrefix) {
+			return nil, Errorf(ErrUntrustedPlugin, "unsupported checksum '%s' in manifest for plugin '%s'",
+				manifest.Checksum, binary)
+		}
+		expected, err := hex.DecodeString(strings.TrimPrefix(manifest.Checksum, checksumPrefix))
+		if err != nil || !bytes.Equal(expected, digest) {
+			return nil, Errorf(ErrUntrustedPlugin, "checksum of plugin '%s' does not match its manifest", binary)
+		}
+	}
+
+	if len(trustedKeys) != 0 {
+		if err := verifySignature(binary, digest, trustedKeys); err != nil {
+			return nil, err
+		}
+	}
+
+	return &plugin.SecureConfig{Checksum: digest, Hash: sha256.New()}, nil
+}
+
+func verifySignature(binary string, digest []byte, trustedKeys []ed25519.PublicKey) error {
+	data, err := ioutil.ReadFile(SignaturePath(binary))
+	if os.IsNotExist(err) {
+		return Errorf(ErrUntrustedPlugin, "plugin '%s' is not signed", binary)
+	} else if err != nil {
+		return err
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return Errorf(ErrUntrustedPlugin, "invalid signature for plugin '%s': %v", binary, err)
+	}
+	for _, key := range trustedKeys {
+		if ed25519.Verify(key, digest, sig) {
+			return nil
+		}
+	}
+	return Errorf(ErrUntrustedPlugin, "plugin '%s' is not signed by a trusted key", binary)
+}
+
+func fileDigest(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, fmt.Errorf("failed to read plugin '%s': %v", path, err)
+	}
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remote
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/*
+ * Copy the echo plugin into a temporary directory, so that manifests and signatures can be written alongside it.
+ */
+func copyEcho(t *testing.T) (string, string) {
+	dir, err := ioutil.TempDir("", "plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile("../build/echo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	binary := filepath.Join(dir, "titan-remote-echo")
+	if err := ioutil.WriteFile(binary, data, 0755); err != nil {
+		t.Fatal(err)
+	}
+	return dir, binary
+}
+
+func writeManifest(t *testing.T, binary string, m Manifest) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(ManifestPath(binary), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func generateKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return public, private
+}
+
+func TestPluginChecksum(t *testing.T) {
+	dir := tempPluginDir(t, map[string]os.FileMode{"titan-remote-s3": 0755})
+	defer os.RemoveAll(dir)
+	sum, err := PluginChecksum(filepath.Join(dir, "titan-remote-s3"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", sum)
+	}
+}
+
+func TestVerifyNothing(t *testing.T) {
+	secure, err := verifyPlugin("/nonexistent", nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, secure)
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	dir, binary := copyEcho(t)
+	defer os.RemoveAll(dir)
+	sum, err := PluginChecksum(binary)
+	if !assert.NoError(t, err) {
+		return
+	}
+	writeManifest(t, binary, Manifest{Checksum: sum})
+
+	reg := NewRegistry()
+	defer reg.Clear()
+	_, err = reg.Load("echo", dir)
+	assert.NoError(t, err)
+}
+
+func TestVerifyChecksumMismatch(t *testing.T) {
+	dir, binary := copyEcho(t)
+	defer os.RemoveAll(dir)
+	writeManifest(t, binary, Manifest{Checksum: "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"})
+
+	reg := NewRegistry()
+	defer reg.Clear()
+	_, err := reg.Load("echo", dir)
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, ErrUntrustedPlugin))
+	}
+	assert.Empty(t, reg.List())
+}
+
+func TestVerifyChecksumUnsupported(t *testing.T) {
+	dir, binary := copyEcho(t)
+	defer os.RemoveAll(dir)
+
+	_, err := verifyPlugin(binary, &Manifest{Checksum: "md5:d41d8cd98f00b204e9800998ecf8427e"}, nil)
+	assert.True(t, errors.Is(err, ErrUntrustedPlugin))
+}
+
+func TestVerifySignature(t *testing.T) {
+	dir, binary := copyEcho(t)
+	defer os.RemoveAll(dir)
+	public, private := generateKey(t)
+	other, _ := generateKey(t)
+	if !assert.NoError(t, SignPlugin(binary, private)) {
+		return
+	}
+
+	reg := NewRegistry(WithTrustedKeys(other, public))
+	defer reg.Clear()
+	_, err := reg.Load("echo", dir)
+	assert.NoError(t, err)
+}
+
+func TestVerifySignatureUntrusted(t *testing.T) {
+	dir, binary := copyEcho(t)
+	defer os.RemoveAll(dir)
+	public, _ := generateKey(t)
+	_, private := generateKey(t)
+	if !assert.NoError(t, SignPlugin(binary, private)) {
+		return
+	}
+
+	_, err := NewRegistry(WithTrustedKeys(public)).Load("echo", dir)
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, ErrUntrustedPlugin))
+	}
+}
+
+func TestVerifySignatureMissing(t *testing.T) {
+	dir, _ := copyEcho(t)
+	defer os.RemoveAll(dir)
+	public, _ := generateKey(t)
+
+	_, err := NewRegistry(WithTrustedKeys(public)).Load("echo", dir)
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, ErrUntrustedPlugin))
+		assert.Contains(t, err.Error(), "is not signed")
+	}
+}
+
+func TestVerifySignatureModified(t *testing.T) {
+	dir, binary := copyEcho(t)
+	defer os.RemoveAll(dir)
+	public, private := generateKey(t)
+	if !assert.NoError(t, SignPlugin(binary, private)) {
+		return
+	}
+	f, err := os.OpenFile(binary, os.O_APPEND|os.O_WRONLY, 0755)
+	if !assert.NoError(t, err) {
+		return
+	}
+	f.Write([]byte{0})
+	f.Close()
+
+	_, err = NewRegistry(WithTrustedKeys(public)).Load("echo", dir)
+	assert.True(t, errors.Is(err, ErrUntrustedPlugin))
+}
@@ -0,0 +1,269 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remotetest
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/titan-data/remote-sdk-go/remote"
+	"sync"
+)
+
+/*
+ * Names of the Remote methods, as recorded by FakeRemote.
+ */
+const (
+	MethodType               = "Type"
+	MethodFromURL            = "FromURL"
+	MethodToURL              = "ToURL"
+	MethodGetParameters      = "GetParameters"
+	MethodValidateRemote     = "ValidateRemote"
+	MethodValidateParameters = "ValidateParameters"
+	MethodListCommits        = "ListCommits"
+	MethodGetCommit          = "GetCommit"
+)
+
+/*
+ * A call made to a FakeRemote, with the arguments in the order they were passed.
+ */
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+/*
+ * A programmable fake remote that records every call made to it. Unlike MockRemote, it needs no expectations: each
+ * method returns a canned response, which defaults to something sensible, and errors can be injected per method. To
+ * test code that looks up remotes, register the fake with remote.Register() so that remote.Get() returns it, and call
+ * remote.LoadInProcess() so that remote.Load() returns it as well, through the plugin interface. The fake is safe for
+ * concurrent use, but its fields must not be changed while it is in use.
+ */
+type FakeRemote struct {
+
+	/*
+	 * Type returned by Type().
+	 */
+	RemoteType string
+
+	/*
+	 * Returned by FromURL(). If nil, the URL is returned as the "url" property, along with the additional properties.
+	 */
+	Properties map[string]interface{}
+
+	/*
+	 * Returned by ToURL(), without additional properties. If empty, the "url" property is returned, with the
+	 * remaining properties as additional properties.
+	 */
+	URL string
+
+	/*
+	 * Returned by GetParameters(). If nil, an empty set of parameters is returned.
+	 */
+	Parameters map[string]interface{}
+
+	/*
+	 * The commits of the remote. ListCommits() returns those matching the tags, sorted by timestamp, and GetCommit()
+	 * returns the commit with the given ID, or nil if there is none.
+	 */
+	Commits []remote.Commit
+
+	mu     sync.Mutex
+	calls  []Call
+	errors map[string]error
+}
+
+/*
+ * Create a new fake remote of the given type, with no commits.
+ */
+func NewFakeRemote(remoteType string) *FakeRemote {
+	return &FakeRemote{RemoteType: remoteType}
+}
+
+/*
+ * Make all subsequent calls to the given method fail with the error. A nil error makes the method succeed again.
+ */
+func (f *FakeRemote) Fail(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.errors == nil {
+		f.errors = map[string]error{}
+	}
+	if err == nil {
+		delete(f.errors, method)
+	} else {
+		f.errors[method] = err
+	}
+}
+
+/*
+ * Returns all calls made so far, in order.
+ */
+func (f *FakeRemote) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call{}, f.calls...)
+}
+
+/*
+ * Returns the calls made so far to the given method, in order.
+ */
+func (f *FakeRemote) CallsTo(method string) []Call {
+	var ret []Call
+	for _, c := range f.Calls() {
+		if c.Method == method {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+/*
+ * Forget all recorded calls and injected errors.
+ */
+func (f *FakeRemote) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+	f.errors = nil
+}
+
+/*
+ * Assert that the method was called with the given arguments. If no arguments are given, any call to the method
+ * matches.
+ */
+func (f *FakeRemote) AssertCalled(t assert.TestingT, method string, args ...interface{}) bool {
+	for _, c := range f.CallsTo(method) {
+		if len(args) == 0 || assert.ObjectsAreEqual(args, c.Args) {
+			return true
+		}
+	}
+	if len(args) == 0 {
+		return assert.Fail(t, fmt.Sprintf("expected a call to %s, but there was none", method))
+	}
+	return assert.Fail(t, fmt.Sprintf("expected a call to %s with arguments %v, but got %v", method, args,
+		f.CallsTo(method)))
+}
+
+/*
+ * Assert that the method was never called.
+ */
+func (f *FakeRemote) AssertNotCalled(t assert.TestingT, method string) bool {
+	calls := f.CallsTo(method)
+	if len(calls) == 0 {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf("expected no calls to %s, but got %v", method, calls))
+}
+
+/*
+ * Assert that the method was called exactly the given number of times.
+ */
+func (f *FakeRemote) AssertNumberOfCalls(t assert.TestingT, method string, expected int) bool {
+	actual := len(f.CallsTo(method))
+	if actual == expected {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf("expected %d calls to %s, but got %d", expected, method, actual))
+}
+
+/*
+ * Record a call, returning the error injected for the method, if any.
+ */
+func (f *FakeRemote) record(method string, args ...interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Method: method, Args: args})
+	return f.errors[method]
+}
+
+func (f *FakeRemote) Type() (string, error) {
+	if err := f.record(MethodType); err != nil {
+		return "", err
+	}
+	return f.RemoteType, nil
+}
+
+func (f *FakeRemote) FromURL(url string, properties map[string]string) (map[string]interface{}, error) {
+	if err := f.record(MethodFromURL, url, properties); err != nil {
+		return nil, err
+	}
+	if f.Properties != nil {
+		return copyProperties(f.Properties), nil
+	}
+	ret := map[string]interface{}{"url": url}
+	for k, v := range properties {
+		ret[k] = v
+	}
+	return ret, nil
+}
+
+func (f *FakeRemote) ToURL(properties map[string]interface{}) (string, map[string]string, error) {
+	if err := f.record(MethodToURL, properties); err != nil {
+		return "", nil, err
+	}
+	if f.URL != "" {
+		return f.URL, map[string]string{}, nil
+	}
+	url, _ := properties["url"].(string)
+	ret := map[string]string{}
+	for k, v := range properties {
+		if k != "url" {
+			ret[k] = fmt.Sprint(v)
+		}
+	}
+	return url, ret, nil
+}
+
+func (f *FakeRemote) GetParameters(properties map[string]interface{}) (map[string]interface{}, error) {
+	if err := f.record(MethodGetParameters, properties); err != nil {
+		return nil, err
+	}
+	if f.Parameters != nil {
+		return copyProperties(f.Parameters), nil
+	}
+	return map[string]interface{}{}, nil
+}
+
+func (f *FakeRemote) ValidateRemote(properties map[string]interface{}) error {
+	return f.record(MethodValidateRemote, properties)
+}
+
+func (f *FakeRemote) ValidateParameters(parameters map[string]interface{}) error {
+	return f.record(MethodValidateParameters, parameters)
+}
+
+func (f *FakeRemote) ListCommits(properties map[string]interface{}, parameters map[string]interface{}, tags []remote.Tag) ([]remote.Commit, error) {
+	if err := f.record(MethodListCommits, properties, parameters, tags); err != nil {
+		return nil, err
+	}
+	ret := []remote.Commit{}
+	for _, c := range f.Commits {
+		if remote.MatchTags(c.Properties, tags) {
+			ret = append(ret, c)
+		}
+	}
+	remote.SortCommits(ret)
+	return ret, nil
+}
+
+func (f *FakeRemote) GetCommit(properties map[string]interface{}, parameters map[string]interface{}, commitId string) (*remote.Commit, error) {
+	if err := f.record(MethodGetCommit, properties, parameters, commitId); err != nil {
+		return nil, err
+	}
+	for _, c := range f.Commits {
+		if c.Id == commitId {
+			commit := c
+			return &commit, nil
+		}
+	}
+	return nil, nil
+}
+
+func copyProperties(properties map[string]interface{}) map[string]interface{} {
+	ret := make(map[string]interface{}, len(properties))
+	for k, v := range properties {
+		ret[k] = v
+	}
+	return ret
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remotetest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/titan-data/remote-sdk-go/remote"
+	"testing"
+)
+
+/*
+ * Records failures reported by assertions, so that failing assertions can be tested.
+ */
+type recordingT struct {
+	failures []string
+}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.failures = append(t.failures, fmt.Sprintf(format, args...))
+}
+
+func fakeWithCommits() *FakeRemote {
+	f := NewFakeRemote("fake")
+	f.Commits = []remote.Commit{
+		{Id: "one", Properties: map[string]interface{}{
+			"timestamp": "2019-09-20T13:45:36Z",
+			"tags":      map[string]interface{}{"name": "one", "env": "dev"},
+		}},
+		{Id: "two", Properties: map[string]interface{}{
+			"timestamp": "2019-09-20T13:45:37Z",
+			"tags":      map[string]interface{}{"name": "two"},
+		}},
+	}
+	return f
+}
+
+func TestFakeConformance(t *testing.T) {
+	Run(t, fakeWithCommits(), Config{URL: "fake://host", Properties: map[string]string{"a": "b"}, CommitId: "one"})
+}
+
+func TestFakeDefaults(t *testing.T) {
+	f := fakeWithCommits()
+	props, err := f.FromURL("fake://host", map[string]string{"a": "b"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{"url": "fake://host", "a": "b"}, props)
+	}
+	u, extra, err := f.ToURL(props)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "fake://host", u)
+		assert.Equal(t, map[string]string{"a": "b"}, extra)
+	}
+	params, err := f.GetParameters(props)
+	if assert.NoError(t, err) {
+		assert.Empty(t, params)
+	}
+	commits, err := f.ListCommits(props, params, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"two", "one"}, []string{commits[0].Id, commits[1].Id})
+	}
+	commit, err := f.GetCommit(props, params, "three")
+	if assert.NoError(t, err) {
+		assert.Nil(t, commit)
+	}
+}
+
+func TestFakeCanned(t *testing.T) {
+	f := NewFakeRemote("fake")
+	f.Properties = map[string]interface{}{"host": "h"}
+	f.URL = "fake://h"
+	f.Parameters = map[string]interface{}{"key": "k"}
+
+	props, _ := f.FromURL("fake://ignored", nil)
+	assert.Equal(t, map[string]interface{}{"host": "h"}, props)
+	u, extra, _ := f.ToURL(props)
+	assert.Equal(t, "fake://h", u)
+	assert.Empty(t, extra)
+	params, _ := f.GetParameters(props)
+	assert.Equal(t, map[string]interface{}{"key": "k"}, params)
+}
+
+func TestFakeFail(t *testing.T) {
+	f := NewFakeRemote("fake")
+	f.Fail(MethodValidateRemote, remote.Errorf(remote.ErrInvalidProperty, "bad"))
+	err := f.ValidateRemote(map[string]interface{}{})
+	assert.True(t, errors.Is(err, remote.ErrInvalidProperty))
+	assert.NoError(t, f.ValidateParameters(map[string]interface{}{}))
+
+	f.Fail(MethodValidateRemote, nil)
+	assert.NoError(t, f.ValidateRemote(map[string]interface{}{}))
+}
+
+func TestFakeCalls(t *testing.T) {
+	f := NewFakeRemote("fake")
+	_, _ = f.Type()
+	_, _ = f.GetCommit(nil, nil, "one")
+	_, _ = f.GetCommit(nil, nil, "two")
+
+	assert.Equal(t, []Call{
+		{Method: MethodType},
+		{Method: MethodGetCommit, Args: []interface{}{map[string]interface{}(nil), map[string]interface{}(nil), "one"}},
+		{Method: MethodGetCommit, Args: []interface{}{map[string]interface{}(nil), map[string]interface{}(nil), "two"}},
+	}, f.Calls())
+	assert.Len(t, f.CallsTo(MethodGetCommit), 2)
+	assert.True(t, f.AssertCalled(t, MethodType))
+	assert.True(t, f.AssertCalled(t, MethodGetCommit, map[string]interface{}(nil), map[string]interface{}(nil), "two"))
+	assert.True(t, f.AssertNotCalled(t, MethodListCommits))
+	assert.True(t, f.AssertNumberOfCalls(t, MethodGetCommit, 2))
+
+	f.Reset()
+	assert.Empty(t, f.Calls())
+}
+
+func TestFakeAssertionFailures(t *testing.T) {
+	f := NewFakeRemote("fake")
+	_, _ = f.GetCommit(nil, nil, "one")
+
+	rt := &recordingT{}
+	assert.False(t, f.AssertCalled(rt, MethodType))
+	assert.False(t, f.AssertCalled(rt, MethodGetCommit, nil, nil, "two"))
+	assert.False(t, f.AssertNotCalled(rt, MethodGetCommit))
+	assert.False(t, f.AssertNumberOfCalls(rt, MethodGetCommit, 2))
+	if assert.Len(t, rt.failures, 4) {
+		assert.Contains(t, rt.failures[0], "expected a call to Type, but there was none")
+		assert.Contains(t, rt.failures[1], "expected a call to GetCommit with arguments")
+		assert.Contains(t, rt.failures[2], "expected no calls to GetCommit")
+		assert.Contains(t, rt.failures[3], "expected 2 calls to GetCommit, but got 1")
+	}
+}
+
+func TestFakeLoad(t *testing.T) {
+	remote.Clear()
+	defer remote.Clear()
+	f := fakeWithCommits()
+	remote.Register(f)
+	_, err := remote.LoadInProcess("fake")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	r, err := remote.Load("fake", "/nonexistent")
+	if assert.NoError(t, err) {
+		commit, err := r.GetCommit(map[string]interface{}{}, map[string]interface{}{}, "two")
+		if assert.NoError(t, err) && assert.NotNil(t, commit) {
+			assert.Equal(t, "two", commit.Id)
+		}
+	}
+	f.AssertCalled(t, MethodGetCommit, map[string]interface{}{}, map[string]interface{}{}, "two")
+	assert.Same(t, f, remote.Get("fake"))
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remotetest
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/titan-data/remote-sdk-go/remote"
+)
+
+/*
+ * A remote built on testify's mock package, for tests that set up expectations for each call:
+ *
+ *     m := new(remotetest.MockRemote)
+ *     m.On("Type").Return("s3", nil)
+ *     m.On("ValidateRemote", mock.Anything).Return(nil)
+ *
+ * Return values are given in the order of the method's results, including the error. Nil maps, lists, and commits
+ * may be passed as untyped nil. If the error is omitted, the call succeeds.
+ */
+type MockRemote struct {
+	mock.Mock
+}
+
+func (r *MockRemote) Type() (string, error) {
+	args := r.Called()
+	return args.String(0), mockError(args, 1)
+}
+
+func (r *MockRemote) FromURL(url string, properties map[string]string) (map[string]interface{}, error) {
+	args := r.Called(url, properties)
+	props, _ := args.Get(0).(map[string]interface{})
+	return props, mockError(args, 1)
+}
+
+func (r *MockRemote) ToURL(properties map[string]interface{}) (string, map[string]string, error) {
+	args := r.Called(properties)
+	props, _ := args.Get(1).(map[string]string)
+	return args.String(0), props, mockError(args, 2)
+}
+
+func (r *MockRemote) GetParameters(properties map[string]interface{}) (map[string]interface{}, error) {
+	args := r.Called(properties)
+	params, _ := args.Get(0).(map[string]interface{})
+	return params, mockError(args, 1)
+}
+
+func (r *MockRemote) ValidateRemote(properties map[string]interface{}) error {
+	return mockError(r.Called(properties), 0)
+}
+
+func (r *MockRemote) ValidateParameters(parameters map[string]interface{}) error {
+	return mockError(r.Called(parameters), 0)
+}
+
+func (r *MockRemote) ListCommits(properties map[string]interface{}, parameters map[string]interface{}, tags []remote.Tag) ([]remote.Commit, error) {
+	args := r.Called(properties, parameters, tags)
+	commits, _ := args.Get(0).([]remote.Commit)
+	return commits, mockError(args, 1)
+}
+
+func (r *MockRemote) GetCommit(properties map[string]interface{}, parameters map[string]interface{}, commitId string) (*remote.Commit, error) {
+	args := r.Called(properties, parameters, commitId)
+	commit, _ := args.Get(0).(*remote.Commit)
+	return commit, mockError(args, 1)
+}
+
+/*
+ * Returns the error at the given index, or nil if there are too few return values.
+ */
+func mockError(args mock.Arguments, index int) error {
+	if len(args) <= index {
+		return nil
+	}
+	return args.Error(index)
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remotetest
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/titan-data/remote-sdk-go/remote"
+	"testing"
+)
+
+func TestMockRemote(t *testing.T) {
+	m := new(MockRemote)
+	m.On("Type").Return("mock")
+	m.On("FromURL", "mock://host", mock.Anything).Return(map[string]interface{}{"host": "host"}, nil)
+	m.On("ToURL", mock.Anything).Return("mock://host", nil, nil)
+	m.On("ValidateRemote", mock.Anything).Return(remote.ErrInvalidProperty)
+	m.On("GetCommit", mock.Anything, mock.Anything, "missing").Return(nil, nil)
+
+	typ, err := m.Type()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "mock", typ)
+	}
+	props, err := m.FromURL("mock://host", nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{"host": "host"}, props)
+	}
+	u, extra, err := m.ToURL(props)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "mock://host", u)
+		assert.Nil(t, extra)
+	}
+	assert.True(t, errors.Is(m.ValidateRemote(props), remote.ErrInvalidProperty))
+	commit, err := m.GetCommit(props, nil, "missing")
+	if assert.NoError(t, err) {
+		assert.Nil(t, commit)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestMockRemoteRegistered(t *testing.T) {
+	reg := remote.NewRegistry()
+	m := new(MockRemote)
+	m.On("Type").Return("mock", nil)
+	m.On("ListCommits", mock.Anything, mock.Anything, mock.Anything).Return([]remote.Commit{{Id: "one"}}, nil)
+	reg.Register(m)
+
+	commits, err := reg.Get("mock").ListCommits(nil, nil, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []remote.Commit{{Id: "one"}}, commits)
+	}
+	m.AssertExpectations(t)
+}
@@ -0,0 +1,403 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remotetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/titan-data/remote-sdk-go/remote"
+	"sort"
+	"strings"
+	"testing"
+)
+
+/*
+ * Conformance suite for Titan remotes. Remote implementations can run it from their own tests to check that they
+ * follow the contract of the Remote interface, both when called directly and when called through the plugin
+ * interface:
+ *
+ *     func TestConformance(t *testing.T) {
+ *         remotetest.Run(t, MyRemote{}, remotetest.Config{URL: "my://host/path"})
+ *     }
+ */
+
+/*
+ * Commit ID used to check GetCommit() for a missing commit if none is configured.
+ */
+const DefaultMissingCommitId = "remotetest-missing-commit"
+
+/*
+ * Maximum number of tag queries derived from the remote's commits when none are configured.
+ */
+const maxDerivedTags = 8
+
+/*
+ * Describes the remote under test. Only the URL is required, the remaining fields enable additional checks or supply
+ * what the remote needs to list its commits.
+ */
+type Config struct {
+
+	/*
+	 * A valid URL for the remote, together with any additional properties to pass to FromURL().
+	 */
+	URL        string
+	Properties map[string]string
+
+	/*
+	 * Parameters passed to ListCommits() and GetCommit(). If nil, they are obtained by calling GetParameters() with
+	 * the remote properties.
+	 */
+	Parameters map[string]interface{}
+
+	/*
+	 * Tag queries to check ListCommits() filtering with. If nil, queries are derived from the tags of the listed
+	 * commits.
+	 */
+	Tags [][]remote.Tag
+
+	/*
+	 * ID of a commit that must exist, checked with GetCommit() if set.
+	 */
+	CommitId string
+
+	/*
+	 * ID of a commit that must not exist, which defaults to DefaultMissingCommitId.
+	 */
+	MissingCommitId string
+
+	/*
+	 * URLs that FromURL() must reject with ErrInvalidURL, and remote properties and parameters that ValidateRemote()
+	 * and ValidateParameters() must reject with ErrInvalidProperty.
+	 */
+	InvalidURLs       []string
+	InvalidRemotes    []map[string]interface{}
+	InvalidParameters []map[string]interface{}
+
+	/*
+	 * Skip repeating the checks through the plugin interface, for remotes that cannot be served as plugins.
+	 */
+	SkipPlugin bool
+}
+
+/*
+ * Run the conformance suite against the given remote. Each check is run as a subtest, first against the remote itself
+ * and then, unless disabled, through the plugin interface by serving the remote in-process.
+ */
+func Run(t *testing.T, r remote.Remote, config Config) {
+	if config.MissingCommitId == "" {
+		config.MissingCommitId = DefaultMissingCommitId
+	}
+	run(t, r, config)
+	if config.SkipPlugin {
+		return
+	}
+	t.Run("Plugin", func(t *testing.T) {
+		typ, err := r.Type()
+		if !assert.NoError(t, err) {
+			return
+		}
+		reg := remote.NewRegistry()
+		defer reg.Clear()
+		reg.Register(r)
+		p, err := reg.LoadInProcess(typ)
+		if assert.NoError(t, err, "failed to serve remote as plugin") {
+			run(t, p, config)
+		}
+	})
+}
+
+func run(t *testing.T, r remote.Remote, config Config) {
+	t.Run("Type", func(t *testing.T) {
+		checkType(t, r, config)
+	})
+	t.Run("URL", func(t *testing.T) {
+		checkURL(t, r, config)
+	})
+	t.Run("Validation", func(t *testing.T) {
+		checkValidation(t, r, config)
+	})
+	t.Run("ListCommits", func(t *testing.T) {
+		checkListCommits(t, r, config)
+	})
+	t.Run("Tags", func(t *testing.T) {
+		checkTags(t, r, config)
+	})
+	t.Run("GetCommit", func(t *testing.T) {
+		checkGetCommit(t, r, config)
+	})
+}
+
+/*
+ * The type must be non-empty, and must match the scheme of the remote's URLs.
+ */
+func checkType(t *testing.T, r remote.Remote, config Config) {
+	typ, err := r.Type()
+	if assert.NoError(t, err) && assert.NotEmpty(t, typ) {
+		assert.True(t, strings.HasPrefix(config.URL, typ+"://"),
+			"URL '%s' does not match remote type '%s'", config.URL, typ)
+	}
+}
+
+/*
+ * Converting a remote to a URL and back must preserve its properties, other than sensitive properties that may have
+ * been redacted by ToURL(). Invalid URLs must be rejected.
+ */
+func checkURL(t *testing.T, r remote.Remote, config Config) {
+	props, ok := fromURL(t, r, config)
+	if !ok {
+		return
+	}
+	u, extra, err := r.ToURL(props)
+	if !assert.NoError(t, err) {
+		return
+	}
+	roundTrip, err := r.FromURL(u, extra)
+	if assert.NoError(t, err, "FromURL() failed for the result of ToURL(): %s", u) {
+		assert.Equal(t, insensitive(r, props), insensitive(r, roundTrip), "properties changed after round trip via %s",
+			u)
+	}
+
+	for _, invalid := range config.InvalidURLs {
+		_, err := r.FromURL(invalid, map[string]string{})
+		if assert.Error(t, err, "FromURL() accepted invalid URL '%s'", invalid) {
+			assert.True(t, errors.Is(err, remote.ErrInvalidURL), "error for '%s' is not ErrInvalidURL: %v",
+				invalid, err)
+		}
+	}
+}
+
+/*
+ * Properties and parameters produced by the remote itself must be valid, and configured invalid ones must be rejected.
+ */
+func checkValidation(t *testing.T, r remote.Remote, config Config) {
+	props, ok := fromURL(t, r, config)
+	if !ok {
+		return
+	}
+	assert.NoError(t, r.ValidateRemote(props), "properties returned by FromURL() are invalid")
+	if params, ok := parameters(t, r, config, props); ok {
+		assert.NoError(t, r.ValidateParameters(params), "parameters are invalid")
+	}
+
+	for _, invalid := range config.InvalidRemotes {
+		err := r.ValidateRemote(invalid)
+		if assert.Error(t, err, "ValidateRemote() accepted invalid properties %v", invalid) {
+			assert.True(t, errors.Is(err, remote.ErrInvalidProperty), "error for %v is not ErrInvalidProperty: %v",
+				invalid, err)
+		}
+	}
+	for _, invalid := range config.InvalidParameters {
+		err := r.ValidateParameters(invalid)
+		if assert.Error(t, err, "ValidateParameters() accepted invalid parameters %v", invalid) {
+			assert.True(t, errors.Is(err, remote.ErrInvalidProperty), "error for %v is not ErrInvalidProperty: %v",
+				invalid, err)
+		}
+	}
+}
+
+/*
+ * Commits must be returned in reverse timestamp order, with unique IDs.
+ */
+func checkListCommits(t *testing.T, r remote.Remote, config Config) {
+	commits, ok := listCommits(t, r, config, nil)
+	if !ok {
+		return
+	}
+	ids := map[string]bool{}
+	for i, c := range commits {
+		assert.NotEmpty(t, c.Id, "commit %d has no ID", i)
+		assert.False(t, ids[c.Id], "commit '%s' is listed more than once", c.Id)
+		ids[c.Id] = true
+		if i > 0 {
+			prev := commits[i-1]
+			assert.False(t, remote.CommitTimestamp(c).After(remote.CommitTimestamp(prev)),
+				"commit '%s' (%v) is listed after older commit '%s' (%v)",
+				c.Id, c.Properties["timestamp"], prev.Id, prev.Properties["timestamp"])
+		}
+	}
+}
+
+/*
+ * Filtering commits by tags must return the same commits as filtering the full list with MatchTags().
+ */
+func checkTags(t *testing.T, r remote.Remote, config Config) {
+	all, ok := listCommits(t, r, config, nil)
+	if !ok {
+		return
+	}
+	queries := config.Tags
+	if queries == nil {
+		queries = deriveTags(all)
+	}
+	for _, query := range queries {
+		var expected []string
+		for _, c := range all {
+			if remote.MatchTags(c.Properties, query) {
+				expected = append(expected, c.Id)
+			}
+		}
+		if filtered, ok := listCommits(t, r, config, query); ok {
+			assert.Equal(t, expected, commitIds(filtered), "wrong commits for tags %s", formatTags(query))
+		}
+	}
+}
+
+/*
+ * GetCommit() must return nil without an error for a missing commit, and the commit itself if it exists.
+ */
+func checkGetCommit(t *testing.T, r remote.Remote, config Config) {
+	props, ok := fromURL(t, r, config)
+	if !ok {
+		return
+	}
+	params, ok := parameters(t, r, config, props)
+	if !ok {
+		return
+	}
+	commit, err := r.GetCommit(props, params, config.MissingCommitId)
+	if assert.NoError(t, err, "GetCommit() must not fail for a missing commit") {
+		assert.Nil(t, commit, "GetCommit() returned a commit for missing ID '%s'", config.MissingCommitId)
+	}
+	if config.CommitId != "" {
+		commit, err := r.GetCommit(props, params, config.CommitId)
+		if assert.NoError(t, err) && assert.NotNil(t, commit, "commit '%s' not found", config.CommitId) {
+			assert.Equal(t, config.CommitId, commit.Id)
+		}
+	}
+}
+
+func fromURL(t *testing.T, r remote.Remote, config Config) (map[string]interface{}, bool) {
+	properties := config.Properties
+	if properties == nil {
+		properties = map[string]string{}
+	}
+	props, err := r.FromURL(config.URL, properties)
+	if !assert.NoError(t, err, "FromURL() failed for %s", config.URL) {
+		return nil, false
+	}
+	return props, true
+}
+
+func parameters(t *testing.T, r remote.Remote, config Config, props map[string]interface{}) (map[string]interface{}, bool) {
+	if config.Parameters != nil {
+		return config.Parameters, true
+	}
+	params, err := r.GetParameters(props)
+	if !assert.NoError(t, err, "GetParameters() failed") {
+		return nil, false
+	}
+	return params, true
+}
+
+func listCommits(t *testing.T, r remote.Remote, config Config, tags []remote.Tag) ([]remote.Commit, bool) {
+	props, ok := fromURL(t, r, config)
+	if !ok {
+		return nil, false
+	}
+	params, ok := parameters(t, r, config, props)
+	if !ok {
+		return nil, false
+	}
+	commits, err := r.ListCommits(props, params, tags)
+	if !assert.NoError(t, err, "ListCommits() failed for tags %s", formatTags(tags)) {
+		return nil, false
+	}
+	return commits, true
+}
+
+/*
+ * Returns the properties without any that are marked as sensitive by the remote's schema.
+ */
+func insensitive(r remote.Remote, properties map[string]interface{}) map[string]interface{} {
+	schema, err := remote.GetSchema(context.Background(), r)
+	if err != nil {
+		return properties
+	}
+	sensitive := remote.SensitiveProperties(schema)
+	ret := map[string]interface{}{}
+	for k, v := range properties {
+		if !contains(sensitive, k) {
+			ret[k] = v
+		}
+	}
+	return ret
+}
+
+/*
+ * Derive tag queries from the commits, querying both by key alone and by key and value. Keys are queried in sorted
+ * order, up to maxDerivedTags queries, along with a tag that no commit should have.
+ */
+func deriveTags(commits []remote.Commit) [][]remote.Tag {
+	values := map[string]string{}
+	for _, c := range commits {
+		for k, v := range commitTags(c) {
+			if _, ok := values[k]; !ok {
+				values[k] = v
+			}
+		}
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var queries [][]remote.Tag
+	for _, k := range keys {
+		if len(queries) >= maxDerivedTags {
+			break
+		}
+		value := values[k]
+		queries = append(queries, []remote.Tag{{Key: k}}, []remote.Tag{{Key: k, Value: &value}})
+	}
+	return append(queries, []remote.Tag{{Key: "remotetest-missing-tag"}})
+}
+
+func commitTags(c remote.Commit) map[string]string {
+	ret := map[string]string{}
+	switch tags := c.Properties["tags"].(type) {
+	case map[string]interface{}:
+		for k, v := range tags {
+			if s, ok := v.(string); ok {
+				ret[k] = s
+			}
+		}
+	case map[string]string:
+		for k, v := range tags {
+			ret[k] = v
+		}
+	}
+	return ret
+}
+
+func commitIds(commits []remote.Commit) []string {
+	var ret []string
+	for _, c := range commits {
+		ret = append(ret, c.Id)
+	}
+	return ret
+}
+
+func formatTags(tags []remote.Tag) string {
+	var ret []string
+	for _, t := range tags {
+		if t.Value == nil {
+			ret = append(ret, t.Key)
+		} else {
+			ret = append(ret, fmt.Sprintf("%s=%s", t.Key, *t.Value))
+		}
+	}
+	return "[" + strings.Join(ret, ", ") + "]"
+}
+
+func contains(arr []string, search string) bool {
+	for _, v := range arr {
+		if v == search {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright The Titan Project Contributors.
+ */
+package remotetest
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/titan-data/remote-sdk-go/internal/echo"
+	"github.com/titan-data/remote-sdk-go/remote"
+	"testing"
+)
+
+func TestEcho(t *testing.T) {
+	Run(t, echo.EchoRemote{}, Config{
+		URL:        "echo://echo",
+		Properties: map[string]string{"a": "b"},
+		CommitId:   "echo",
+	})
+}
+
+func TestDeriveTags(t *testing.T) {
+	one, two := "one", "two"
+	commits := []remote.Commit{
+		{Id: "a", Properties: map[string]interface{}{"tags": map[string]interface{}{"name": one}}},
+		{Id: "b", Properties: map[string]interface{}{"tags": map[string]string{"name": two, "env": "prod"}}},
+		{Id: "c", Properties: map[string]interface{}{}},
+	}
+	env := "prod"
+	assert.Equal(t, [][]remote.Tag{
+		{{Key: "env"}},
+		{{Key: "env", Value: &env}},
+		{{Key: "name"}},
+		{{Key: "name", Value: &one}},
+		{{Key: "remotetest-missing-tag"}},
+	}, deriveTags(commits))
+}
+
+func TestFormatTags(t *testing.T) {
+	v := "b"
+	assert.Equal(t, "[a=b, c]", formatTags([]remote.Tag{{Key: "a", Value: &v}, {Key: "c"}}))
+	assert.Equal(t, "[]", formatTags(nil))
+}